@@ -5,6 +5,7 @@
 package mccrud
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"github.com/abbeymart/mcresponse"
@@ -21,6 +22,8 @@ type AccessInfoType struct {
 	RoleIds  []string
 	IsAdmin  bool
 	IsActive bool
+	Profile  Profile
+	Expire   int64 // token expire in milliseconds, if known
 }
 
 // TaskPermissionType for TaskPermission method value (interface{}) response,
@@ -32,6 +35,7 @@ type TaskPermissionType struct {
 	UserId   string
 	Role     string
 	Roles    []string
+	Decision AccessDecisionType
 }
 
 func (crud *Crud) CheckTaskType() string {
@@ -57,6 +61,7 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 	// validate current user active status: by token (API) and user/loggedIn-status
 	accessRes := crud.CheckUserAccess()
 	if accessRes.Code != "success" {
+		crud.RecordAccessDecision(AccessDecisionType{Rule: AccessRuleAuthentication, Reason: accessRes.Message})
 		return accessRes
 	}
 	// set current-user info for next steps
@@ -69,11 +74,16 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 	)
 	val, ok := accessRes.Value.(AccessInfoType)
 	if !ok {
+		decision := crud.RecordAccessDecision(AccessDecisionType{
+			Rule:   AccessRuleAuthentication,
+			Reason: "Error parsing user access information/value",
+		})
 		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-			Message: "Error parsing user access information/value",
+			Message: decision.Reason,
 			Value:   nil,
 		})
 	}
+	crud.AccessInfo = val
 	uId = val.UserId
 	roleId = val.RoleId
 	roleIds = val.RoleIds
@@ -81,18 +91,21 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 	isActive = val.IsActive
 	// determine records/documents ownership, for all records (atomic)
 	ownerPermitted := false
+	var ownedIds []string
 	idLen := len(crud.RecordIds)
 	if idLen > 0 && uId != "" && isActive {
 		// SQL script
 		inValues := ""
 		for idCount, id := range crud.RecordIds {
-			inValues += "'" + id + "'"
+			inValues += QuoteSQLString(id)
 			if idLen > 1 && idCount < idLen-1 {
 				inValues += ", "
 			}
 		}
-		sqlScript := fmt.Sprintf("SELECT id FROM %v WHERE id IN (%v) AND created_by = $1", crud.TableName, inValues)
-		rows, err := crud.AccessDb.Queryx(sqlScript, uId)
+		// scope ownership-query to the app/tenant, in multi-tenant mode
+		whereScript, sqlValues := crud.TenantWhere(fmt.Sprintf("WHERE id IN (%v) AND created_by = $1", inValues), []interface{}{uId})
+		sqlScript := fmt.Sprintf("SELECT id FROM %v %v", crud.TableName, whereScript)
+		rows, err := crud.AccessDb.Queryx(sqlScript, sqlValues...)
 		if err != nil {
 			errMsg := fmt.Sprintf("Db query Error: %v", err.Error())
 			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
@@ -112,6 +125,7 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 			var id string
 			if recErr := rows.Scan(&id); recErr == nil {
 				rowCount += 1
+				ownedIds = append(ownedIds, id)
 			}
 		}
 		// ensure complete records count, as requested
@@ -119,18 +133,37 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 			ownerPermitted = true
 		}
 	}
+	// per-record shares/grants to the user or the user groups (roles)
+	var shares []ShareType
+	if crud.ShareAccess && idLen > 0 && uId != "" && isActive && !ownerPermitted {
+		var shErr error
+		shares, shErr = crud.GetUserShares(uId, append([]string{roleId}, roleIds...), crud.RecordIds)
+		if shErr != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Db query Error: %v", shErr.Error()),
+				Value:   nil,
+			})
+		}
+	}
 	// if all the above checks passed, check for role-services access by taskType
 	// obtain table/collName id(_id) from serviceTable/Coll (repo for all resources)
-	var (
-		serviceId string
-		category  string
-	)
-	serviceScript := fmt.Sprintf("SELECT id, category from %v WHERE name=$1", crud.ServiceTable)
-	serviceRow := crud.AccessDb.QueryRow(serviceScript, crud.TableName)
+	service, err := crud.GetTableService(isAdmin && isActive)
+	serviceId := service.Id
+	category := service.Category
 	// check error
-	if err := serviceRow.Scan(&serviceId, &category); err != nil {
+	if err != nil {
+		decision := crud.RecordAccessDecision(AccessDecisionType{
+			UserId:         uId,
+			RoleId:         roleId,
+			RoleIds:        roleIds,
+			IsAdmin:        isAdmin,
+			IsActive:       isActive,
+			OwnerPermitted: ownerPermitted,
+			Rule:           AccessRuleService,
+			Reason:         fmt.Sprintf("Unauthorized: service information not found for %v | %v", crud.TableName, err.Error()),
+		})
 		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Unauthorized: user information not found or inactive | %v", err.Error()),
+			Message: decision.Reason,
 			Value:   nil,
 		})
 	}
@@ -146,59 +179,146 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 	var roleServices []RoleServiceType
 	var rsErr error
 	if len(serviceIds) > 0 {
-		roleServices, rsErr = crud.GetRoleServices(crud.AccessDb, crud.RoleTable, roleId, serviceIds)
+		if crud.RoleHierarchy {
+			// union of the user roles (roleId and roleIds), and the inherited (parent) roles
+			roleServices, rsErr = crud.GetEffectiveRoleServices(uId, append([]string{roleId}, roleIds...), serviceIds)
+		} else {
+			roleServices, rsErr = crud.GetCachedRoleServices(uId, roleId, serviceIds)
+		}
 		if rsErr != nil {
+			decision := crud.RecordAccessDecision(AccessDecisionType{
+				UserId:         uId,
+				RoleId:         roleId,
+				RoleIds:        roleIds,
+				IsAdmin:        isAdmin,
+				IsActive:       isActive,
+				OwnerPermitted: ownerPermitted,
+				Rule:           AccessRuleNoRoleService,
+				Reason:         fmt.Sprintf("Action un-authorised / not-permitted | %v", rsErr.Error()),
+			})
 			return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-				Message: fmt.Sprintf("Action un-authorised / not-permitted | %v", rsErr.Error()),
+				Message: decision.Reason,
 				Value:   nil,
 			})
 		}
 	}
 
+	// field/column-level permissions, for the table-service (not applicable to admin users)
+	var fieldPermissions []FieldPermissionType
+	if crud.CheckFieldAccess && !isAdmin && tableId != "" {
+		for _, roleService := range roleServices {
+			if roleService.ServiceId == tableId {
+				fieldPermissions = append(fieldPermissions, roleService.FieldPermissions...)
+			}
+		}
+	}
+	crud.FieldPermissions = fieldPermissions
+
 	permittedRes := CheckAccessType{
+		UserId:           uId,
+		RoleId:           roleId,
+		RoleIds:          roleIds,
+		IsActive:         isActive,
+		IsAdmin:          isAdmin,
+		RoleServices:     roleServices,
+		TableId:          tableId,
+		OwnerPermitted:   ownerPermitted,
+		FieldPermissions: fieldPermissions,
+		Shares:           shares,
+	}
+
+	decision := AccessDecisionType{
 		UserId:         uId,
 		RoleId:         roleId,
 		RoleIds:        roleIds,
-		IsActive:       isActive,
 		IsAdmin:        isAdmin,
+		IsActive:       isActive,
 		RoleServices:   roleServices,
-		TableId:        tableId,
 		OwnerPermitted: ownerPermitted,
 	}
 
 	if permittedRes.IsActive && permittedRes.IsAdmin {
+		decision.Allowed = true
+		decision.Rule = AccessRuleAdmin
+		decision.Reason = "Action authorised / permitted."
+		permittedRes.Decision = crud.RecordAccessDecision(decision)
 		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-			Message: "Action authorised / permitted.",
+			Message: decision.Reason,
 			Value:   permittedRes,
 		})
 	}
 	recLen := len(permittedRes.RoleServices)
 	if permittedRes.IsActive && recLen > 0 && recLen >= len(crud.RecordIds) {
+		decision.Allowed = true
+		decision.Rule = AccessRuleRecord
+		if tableId != "" && ArrayStringContains(RoleServiceIds(roleServices), tableId) {
+			decision.Rule = AccessRuleTable
+		}
+		decision.Reason = fmt.Sprintf("Access permitted for %v of %v service-items/records", recLen, len(crud.RecordIds))
+		permittedRes.Decision = crud.RecordAccessDecision(decision)
 		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Access permitted for %v of %v service-items/records", recLen, len(crud.RecordIds)),
+			Message: decision.Reason,
 			Value:   permittedRes,
 		})
 	}
+	// records owner, or shared records, the task permission is determined by TaskPermissionById
+	sharedIds := ShareRecordIds(shares)
+	if permittedRes.IsActive && idLen > 0 && (ownerPermitted || (len(shares) > 0 && len(MissingRecordIds(crud.RecordIds, append(sharedIds, RoleServiceIds(roleServices)...))) == 0)) {
+		decision.Allowed = true
+		decision.Rule = AccessRuleOwner
+		decision.Reason = fmt.Sprintf("Access permitted, as owner, for %v service-items/records", idLen)
+		if !ownerPermitted {
+			decision.Rule = AccessRuleShare
+			decision.Reason = fmt.Sprintf("Access permitted, as shared, for %v of %v service-items/records", len(sharedIds), idLen)
+		}
+		permittedRes.Decision = crud.RecordAccessDecision(decision)
+		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+			Message: decision.Reason,
+			Value:   permittedRes,
+		})
+	}
+	if !permittedRes.IsActive {
+		decision.Rule = AccessRuleInactive
+		decision.Reason = "Action un-authorised / not-permitted: account is not active."
+	} else {
+		decision.Rule = AccessRuleNoRoleService
+		decision.MissingRecordIds = MissingRecordIds(MissingRecordIds(crud.RecordIds, sharedIds), RoleServiceIds(roleServices))
+		decision.Reason = fmt.Sprintf("Action un-authorised / not-permitted: role-services found for %v of %v service-items/records", recLen, len(crud.RecordIds))
+	}
+	permittedRes.Decision = crud.RecordAccessDecision(decision)
 	return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-		Message: "Action authorised / permitted.",
+		Message: decision.Reason,
 		Value:   permittedRes,
 	})
 }
 
 // GetRoleServices method process and returns the permission to user / user-group/roleId for the specified service items
 func (crud *Crud) GetRoleServices(accessDb *sqlx.DB, roleTable string, userRoleId string, serviceIds []string) ([]RoleServiceType, error) {
+	return crud.GetRoleServicesByRoleIds(accessDb, roleTable, []string{userRoleId}, serviceIds)
+}
+
+// GetRoleServicesByRoleIds method process and returns the permissions of the roleIds for the specified service items,
+// or for all the service items, if serviceIds is empty
+func (crud *Crud) GetRoleServicesByRoleIds(accessDb *sqlx.DB, roleTable string, userRoleIds []string, serviceIds []string) ([]RoleServiceType, error) {
 	var roleServices []RoleServiceType
 	// where-in-values
-	inValues := ""
-	idLen := len(serviceIds)
-	for idCount, id := range serviceIds {
-		inValues += "'" + id + "'"
-		if idLen > 1 && idCount < idLen-1 {
-			inValues += ", "
-		}
-	}
-	roleScript := fmt.Sprintf("SELECT role_id, service_id, service_category, can_read, can_create, can_delete, can_update, can_crud from %v WHERE service_id IN (%v) AND role_id=$1 AND is_active=$2", roleTable, inValues)
-	rows, err := accessDb.Queryx(roleScript, userRoleId, true)
+	inValues := ArrayToSQLStringValues(serviceIds)
+	roleInValues := ArrayToSQLStringValues(userRoleIds)
+	roleFields := "role_id, service_id, service_category, can_read, can_create, can_delete, can_update, can_crud"
+	if crud.CheckFieldAccess {
+		// field/column-level permissions, json-array of FieldPermissionType
+		roleFields += ", field_permissions"
+	}
+	if crud.RoleHierarchy {
+		// deny role-service, overrides allow role-services
+		roleFields += ", is_deny"
+	}
+	roleScript := fmt.Sprintf("SELECT %v from %v WHERE service_id IN (%v) AND role_id IN (%v) AND is_active=$1", roleFields, roleTable, inValues, roleInValues)
+	if len(serviceIds) < 1 {
+		// all the role-services of the roleIds
+		roleScript = fmt.Sprintf("SELECT %v from %v WHERE role_id IN (%v) AND is_active=$1", roleFields, roleTable, roleInValues)
+	}
+	rows, err := accessDb.Queryx(roleScript, true)
 	if err != nil {
 		//errMsg := fmt.Sprintf("Db query Error: %v", err.Error())
 		return roleServices, errors.New(fmt.Sprintf("%v", err.Error()))
@@ -212,10 +332,19 @@ func (crud *Crud) GetRoleServices(accessDb *sqlx.DB, roleTable string, userRoleI
 	var (
 		roleId, serviceId, serviceCategory                string
 		canRead, canCreate, canDelete, canUpdate, canCrud bool
+		fieldPermissions                                  sql.NullString
+		isDeny                                            bool
 	)
 	for rows.Next() {
-		if err := rows.Scan(&roleId, &serviceId, &serviceCategory, &canRead, &canCreate, &canDelete, &canUpdate, &canCrud); err == nil {
-			roleServices = append(roleServices, RoleServiceType{
+		scanValues := []interface{}{&roleId, &serviceId, &serviceCategory, &canRead, &canCreate, &canDelete, &canUpdate, &canCrud}
+		if crud.CheckFieldAccess {
+			scanValues = append(scanValues, &fieldPermissions)
+		}
+		if crud.RoleHierarchy {
+			scanValues = append(scanValues, &isDeny)
+		}
+		if err := rows.Scan(scanValues...); err == nil {
+			roleService := RoleServiceType{
 				ServiceId:       serviceId,
 				RoleId:          roleId,
 				ServiceCategory: serviceCategory,
@@ -224,7 +353,16 @@ func (crud *Crud) GetRoleServices(accessDb *sqlx.DB, roleTable string, userRoleI
 				CanUpdate:       canUpdate,
 				CanDelete:       canDelete,
 				CanCrud:         canCrud,
-			})
+				IsDeny:          isDeny,
+			}
+			if crud.CheckFieldAccess && fieldPermissions.Valid && fieldPermissions.String != "" {
+				fieldPerms, fpErr := ParseFieldPermissions(fieldPermissions.String)
+				if fpErr != nil {
+					return roleServices, fpErr
+				}
+				roleService.FieldPermissions = fieldPerms
+			}
+			roleServices = append(roleServices, roleService)
 		}
 	}
 
@@ -241,6 +379,7 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 		ownerPermitted  = false
 		recordPermitted = false
 		tablePermitted  = false
+		sharePermitted  = false
 		isAdmin         = false
 		isActive        = false
 		userId          = ""
@@ -248,6 +387,7 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 		group           = ""
 		groups          []string
 		roleServices    []RoleServiceType
+		shares          []ShareType
 	)
 	// check role-based access
 	accessRes := crud.CheckTaskAccess()
@@ -272,18 +412,29 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 	group = accessRec.RoleId
 	groups = accessRec.RoleIds
 	tableId = accessRec.TableId
+	shares = accessRec.Shares
+	// access decision explanation
+	decision := accessRec.Decision
+	decision.TaskType = taskType
 	// validate active status
 	if !isActive {
+		decision.Allowed = false
+		decision.Rule = AccessRuleInactive
+		decision.Reason = "Account is not active. Validate active status"
 		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-			Message: "Account is not active. Validate active status",
-			Value:   nil,
+			Message: decision.Reason,
+			Value:   TaskPermissionType{Decision: crud.RecordAccessDecision(decision)},
 		})
 	}
 	// validate roleServices permission, for non-admin/non-owner users
-	if !isAdmin && !ownerPermitted && len(roleServices) < 1 {
+	if !isAdmin && !ownerPermitted && len(roleServices) < 1 && len(shares) < 1 {
+		decision.Allowed = false
+		decision.Rule = AccessRuleNoRoleService
+		decision.MissingRecordIds = crud.RecordIds
+		decision.Reason = "You are not authorized to perform the requested action/task"
 		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-			Message: "You are not authorized to perform the requested action/task",
-			Value:   nil,
+			Message: decision.Reason,
+			Value:   TaskPermissionType{Decision: crud.RecordAccessDecision(decision)},
 		})
 	}
 	// filter the roleServices by categories ("collection | table" or "record | document")
@@ -344,6 +495,7 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 		return false
 	}
 	// taskType specific permission(s)
+	var taskRoleFunc RoleFuncType
 	if !isAdmin && len(roleServices) > 0 {
 		switch taskType {
 		case CreateTask, InsertTask:
@@ -372,6 +524,7 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 				}()
 			}
 			// document/record level access: all recordIds must have at least a match in the roleRecords
+			taskRoleFunc = roleUpdateFunc
 			if len(recordIds) > 0 {
 				recordPermitted = func() bool {
 					for _, v := range recordIds {
@@ -395,6 +548,7 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 				}()
 			}
 			// document/record level access: all recordIds must have at least a match in the roleRecords
+			taskRoleFunc = roleDeleteFunc
 			if len(recordIds) > 0 {
 				recordPermitted = func() bool {
 					for _, v := range recordIds {
@@ -418,6 +572,7 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 				}()
 			}
 			// document/record level access: all recordIds must have at least a match in the roleRecords
+			taskRoleFunc = roleReadFunc
 			if len(recordIds) > 0 {
 				recordPermitted = func() bool {
 					for _, v := range recordIds {
@@ -429,27 +584,69 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 				}()
 			}
 		default:
+			decision.Allowed = false
+			decision.Rule = AccessRuleUnknownTask
+			decision.Reason = "Unknown access type or access type not specified."
 			return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-				Message: "Unknown access type or access type not specified.",
-				Value:   nil,
+				Message: decision.Reason,
+				Value:   TaskPermissionType{Decision: crud.RecordAccessDecision(decision)},
 			})
 		}
 	}
 
+	// shared records access: all recordIds must be shared (read, write/update) or permitted by the roleRecords
+	if !isAdmin && len(shares) > 0 && len(recordIds) > 0 {
+		var roleRecordIds []string
+		for _, v := range recordIds {
+			if taskRoleFunc != nil && roleRecFunc(v, roleRecords, taskRoleFunc) {
+				roleRecordIds = append(roleRecordIds, v)
+			}
+		}
+		sharePermitted = SharePermitted(shares, taskType, MissingRecordIds(recordIds, roleRecordIds))
+	}
+
 	// overall access permitted
-	taskPermitted = recordPermitted || tablePermitted || ownerPermitted || isAdmin
+	taskPermitted = recordPermitted || tablePermitted || ownerPermitted || sharePermitted || isAdmin
+
+	// matched role-services, for the table and the records
+	decision.RoleServices = append(append([]RoleServiceType{}, roleTables...), roleRecords...)
+	decision.Allowed = taskPermitted
+	switch {
+	case isAdmin:
+		decision.Rule = AccessRuleAdmin
+	case tablePermitted:
+		decision.Rule = AccessRuleTable
+	case recordPermitted:
+		decision.Rule = AccessRuleRecord
+	case ownerPermitted:
+		decision.Rule = AccessRuleOwner
+	case sharePermitted:
+		decision.Rule = AccessRuleShare
+	default:
+		decision.Rule = AccessRuleNoRoleService
+	}
 
 	if !taskPermitted {
+		// records not owned (all-or-none), not shared and without the task role-service permission
+		for _, v := range recordIds {
+			if (taskRoleFunc == nil || !roleRecFunc(v, roleRecords, taskRoleFunc)) && !SharePermitted(shares, taskType, []string{v}) {
+				decision.MissingRecordIds = append(decision.MissingRecordIds, v)
+			}
+		}
+		decision.Reason = fmt.Sprintf("You are not authorized to perform the requested action/task (%v).", taskType)
 		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-			Message: "You are not authorized to perform the requested action/task.",
+			Message: decision.Reason,
 			Value: TaskPermissionType{
-				Ok: taskPermitted,
+				Ok:       taskPermitted,
+				Decision: crud.RecordAccessDecision(decision),
 			},
 		})
 	}
 	// if all went well
+	decision.MissingRecordIds = nil
+	decision.Reason = "Action authorised / permitted."
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-		Message: "Action authorised / permitted.",
+		Message: decision.Reason,
 		Value: TaskPermissionType{
 			Ok:       taskPermitted,
 			IsAdmin:  isAdmin,
@@ -457,6 +654,7 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 			UserId:   userId,
 			Role:     group,
 			Roles:    groups,
+			Decision: crud.RecordAccessDecision(decision),
 		},
 	})
 }
@@ -493,95 +691,51 @@ func (crud *Crud) TaskPermissionByParam(taskType string) mcresponse.ResponseMess
 	return crud.TaskPermissionById(taskType)
 }
 
-// CheckUserAccess method determines the user access status: active, valid login and admin
+// CheckUserAccess method determines the user access status: active, valid login and admin.
+// It delegates to the crud Authenticator, or the DbTokenAuthenticator (accesses-table token lookup) by default.
 func (crud *Crud) CheckUserAccess() mcresponse.ResponseMessage {
-	// validate current user active status: by token (API) and user/loggedIn-status
-	// get the accessKey information for the user
-	accessScript := fmt.Sprintf("SELECT expire from %v WHERE user_id=$1 AND token=$2 AND login_name=$3", crud.AccessTable)
-	rowAccess := crud.AccessDb.QueryRow(accessScript, crud.UserInfo.UserId, crud.UserInfo.Token, crud.UserInfo.LoginName)
-	// check login-status/expiration
-	var accessExpire int64
-	if err := rowAccess.Scan(&accessExpire); err != nil {
-		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Unauthorized: please ensure that you are logged-in: %v", err.Error()),
-			Value:   nil,
-		})
-	} else {
-		if (time.Now().Unix() * 1000) > accessExpire {
-			return mcresponse.GetResMessage("tokenExpired", mcresponse.ResponseMessageOptions{
-				Message: "Access expired: please login to continue",
-				Value:   nil,
+	// cached access information, by authenticator and user-token
+	if crud.AccessCache {
+		if accessInfo, ok := GetAccessCache(crud.accessCacheScope(), crud.UserInfo); ok {
+			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+				Message: "Action authorised / permitted.",
+				Value:   accessInfo,
 			})
 		}
 	}
-	// check the current-user status/info
-	var (
-		uId      string
-		roleIds  interface{} // IDs type
-		isAdmin  bool
-		isActive bool
-		profile  interface{} // Profile type
-	)
-	userScript := fmt.Sprintf("SELECT id, role_ids, is_admin, profile, is_active from %v WHERE id=$1 AND is_active=$2", crud.UserTable)
-	rowUser := crud.AccessDb.QueryRow(userScript, crud.UserInfo.UserId, true)
-	if err := rowUser.Scan(&uId, &roleIds, &isAdmin, &profile, &isActive); err != nil {
-		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Unauthorized: user information not found or is inactive: %v", err.Error()),
-			Value:   nil,
-		})
-	}
-	// transform, roleIds and profile (base64String-values) to roleIdsVal and profileVal
-	roleIdsModel := IDs{}
-	profileModel := Profile{}
-
-	rIdsVal, rErr := ConvertJsonBase64StringToTypeValue(roleIds, &roleIdsModel)
-	if rErr != nil {
-		rIdsVal = roleIdsModel
-		//return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-		//	Message: fmt.Sprintf("Error parsing roleIds-json-value: %v", rErr.Error()),
-		//	Value:   nil,
-		//})
-	}
-	pVal, pErr := ConvertJsonBase64StringToTypeValue(profile, &profileModel)
-	if pErr != nil {
-		pVal = profileModel
-		//return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-		//	Message: fmt.Sprintf("Error parsing user-profile-json-value: %v", pErr.Error()),
-		//	Value:   nil,
-		//})
-	}
-
-	roleIdsVal, rOk := rIdsVal.(IDs)
-	if !rOk {
-		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error asserting-type of the parsed roleIds-json-value"),
-			Value:   nil,
-		})
+	var accessRes mcresponse.ResponseMessage
+	if crud.Authenticator != nil {
+		accessRes = crud.Authenticator.Authenticate(crud.UserInfo)
+	} else {
+		accessRes = NewDbTokenAuthenticator(crud.AccessDb, crud.AccessTable, crud.UserTable).Authenticate(crud.UserInfo)
 	}
-	profileVal, pOk := pVal.(Profile)
-	if !pOk {
-		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error asserting-type of the parsed user-profile-json-value"),
-			Value:   nil,
-		})
+	if accessInfo, ok := accessRes.Value.(AccessInfoType); ok && crud.AccessCache && accessRes.Code == "success" {
+		SetAccessCache(crud.accessCacheScope(), crud.UserInfo, accessInfo, crud.AccessCacheExpire)
 	}
-
-	// if all went well
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-		Message: "Action authorised / permitted.",
-		Value: AccessInfoType{
-			UserId:   uId,
-			RoleId:   profileVal.RoleId,
-			RoleIds:  roleIdsVal,
-			IsAdmin:  isAdmin,
-			IsActive: isActive,
-		},
-	})
+	return accessRes
 }
 
 // CheckLoginStatus method checks if the user exists and has active login status/token
 func (crud *Crud) CheckLoginStatus() mcresponse.ResponseMessage {
 	params := crud.UserInfo
+	// validate by the crud Authenticator, if specified, e.g. offline JWT validation
+	if crud.Authenticator != nil {
+		authRes := crud.Authenticator.Authenticate(params)
+		if authRes.Code != "success" {
+			return authRes
+		}
+		accessInfo, ok := authRes.Value.(AccessInfoType)
+		if !ok {
+			return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+				Message: "Error parsing user access information/value",
+				Value:   nil,
+			})
+		}
+		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+			Message: "Action authorised / Access permitted.",
+			Value:   accessInfo.UserId,
+		})
+	}
 	// check if user exists, from users table
 	emailUsername := EmailUsername(params.LoginName)
 	email := emailUsername.Email
@@ -0,0 +1,293 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: access-context (user access information and table-services) cache, by user-token
+
+package mccrud
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// accessCacheItem is the cached access information of the user-token
+type accessCacheItem struct {
+	tokenHash  string
+	accessInfo AccessInfoType
+	expire     time.Time
+}
+
+// serviceCacheItem is the cached (table) service of the service table
+type serviceCacheItem struct {
+	service ServiceType
+	expire  time.Time
+}
+
+var (
+	accessCache       = map[string]accessCacheItem{}
+	accessCacheMutex  sync.RWMutex
+	serviceCache      = map[string]serviceCacheItem{}
+	serviceCacheMutex sync.RWMutex
+)
+
+// accessCacheKey computes the cache-key of the authenticator scope (authScope) and the user-information (userId,
+// loginName and token)
+func accessCacheKey(authScope string, userInfo UserInfoType) string {
+	return HashToken(fmt.Sprintf("%v|%v|%v|%v", authScope, userInfo.UserId, userInfo.LoginName, userInfo.Token))
+}
+
+// accessCacheScope method returns the authenticator scope of the cached access information: the Authenticator
+// instance, or the access db and tables of the DbTokenAuthenticator (default), so that the access information
+// validated by an authenticator is not reused by the cruds of the other authenticators (e.g. jwt secrets, access dbs)
+func (crud *Crud) accessCacheScope() string {
+	if crud.Authenticator != nil {
+		if reflect.ValueOf(crud.Authenticator).Kind() == reflect.Ptr {
+			return fmt.Sprintf("%T|%p", crud.Authenticator, crud.Authenticator)
+		}
+		return fmt.Sprintf("%T|%#v", crud.Authenticator, crud.Authenticator)
+	}
+	return fmt.Sprintf("db|%p|%v|%v", crud.AccessDb, crud.AccessTable, crud.UserTable)
+}
+
+// AccessCacheExpireTime function returns the cache expire time of the access information, bounded by the
+// token expire (milliseconds), if specified
+func AccessCacheExpireTime(now time.Time, cacheExpire int, tokenExpire int64) time.Time {
+	expire := now.Add(time.Duration(cacheExpire) * time.Second)
+	if tokenExpire > 0 {
+		if tokenExpireTime := time.Unix(0, tokenExpire*int64(time.Millisecond)); tokenExpireTime.Before(expire) {
+			expire = tokenExpireTime
+		}
+	}
+	return expire
+}
+
+// GetAccessCache function returns the cached (un-expired) access information of the authenticator scope and
+// user-information
+func GetAccessCache(authScope string, userInfo UserInfoType) (AccessInfoType, bool) {
+	key := accessCacheKey(authScope, userInfo)
+	accessCacheMutex.RLock()
+	cacheItem, ok := accessCache[key]
+	accessCacheMutex.RUnlock()
+	if !ok {
+		return AccessInfoType{}, false
+	}
+	if !time.Now().Before(cacheItem.expire) {
+		accessCacheMutex.Lock()
+		delete(accessCache, key)
+		accessCacheMutex.Unlock()
+		return AccessInfoType{}, false
+	}
+	return cacheItem.accessInfo, true
+}
+
+// SetAccessCache function caches the access information of the authenticator scope and user-information, for
+// cacheExpire secs, or until the token expire
+func SetAccessCache(authScope string, userInfo UserInfoType, accessInfo AccessInfoType, cacheExpire int) {
+	expire := AccessCacheExpireTime(time.Now(), cacheExpire, accessInfo.Expire)
+	if !time.Now().Before(expire) || !accessInfo.IsActive {
+		return
+	}
+	accessCacheMutex.Lock()
+	defer accessCacheMutex.Unlock()
+	accessCache[accessCacheKey(authScope, userInfo)] = accessCacheItem{
+		tokenHash:  HashToken(userInfo.Token),
+		accessInfo: accessInfo,
+		expire:     expire,
+	}
+}
+
+// InvalidateAccessCache removes the cached access information of the user-token, e.g. after logout
+func InvalidateAccessCache(token string) {
+	tokenHash := HashToken(token)
+	accessCacheMutex.Lock()
+	defer accessCacheMutex.Unlock()
+	for key, cacheItem := range accessCache {
+		if cacheItem.tokenHash == tokenHash {
+			delete(accessCache, key)
+		}
+	}
+}
+
+// InvalidateUserAccessCache removes the cached access information (all tokens) and the effective role-services
+// of the user, e.g. after the user deactivation, roles or sessions changes
+func InvalidateUserAccessCache(userId string) {
+	accessCacheMutex.Lock()
+	for key, cacheItem := range accessCache {
+		if cacheItem.accessInfo.UserId == userId {
+			delete(accessCache, key)
+		}
+	}
+	accessCacheMutex.Unlock()
+	InvalidateRoleCache(userId)
+}
+
+// InvalidateServiceCache removes the cached service, by name, e.g. after the service changes
+func InvalidateServiceCache(serviceName string) {
+	serviceCacheMutex.Lock()
+	defer serviceCacheMutex.Unlock()
+	for key := range serviceCache {
+		if strings.HasSuffix(key, "|"+serviceName) {
+			delete(serviceCache, key)
+		}
+	}
+}
+
+// ClearAccessCache removes the cached access information, services and role-services of all users
+func ClearAccessCache() {
+	accessCacheMutex.Lock()
+	accessCache = map[string]accessCacheItem{}
+	accessCacheMutex.Unlock()
+	serviceCacheMutex.Lock()
+	serviceCache = map[string]serviceCacheItem{}
+	serviceCacheMutex.Unlock()
+	ClearRoleCache()
+}
+
+// GetTableService method returns the (cached) service of the crud table, and registers the table-service,
+// if not found, AutoRegisterService and isAdmin (the current user is an active admin)
+func (crud *Crud) GetTableService(isAdmin bool) (ServiceType, error) {
+	cacheKey := crud.ServiceTable + "|" + crud.TableName
+	if crud.AccessCache {
+		serviceCacheMutex.RLock()
+		cacheItem, ok := serviceCache[cacheKey]
+		serviceCacheMutex.RUnlock()
+		if ok && time.Now().Before(cacheItem.expire) {
+			return cacheItem.service, nil
+		}
+	}
+	service := ServiceType{Name: crud.TableName}
+	serviceScript := fmt.Sprintf("SELECT id, category from %v WHERE name=$1", crud.ServiceTable)
+	err := crud.AccessDb.QueryRow(serviceScript, crud.TableName).Scan(&service.Id, &service.Category)
+	if err == sql.ErrNoRows && crud.AutoRegisterService && isAdmin {
+		// register the crud table, as table-service: admin users only
+		service, err = crud.SaveService(crud.TableName, ServiceCategoryTable)
+	}
+	if err != nil {
+		return service, err
+	}
+	if crud.AccessCache {
+		serviceCacheMutex.Lock()
+		serviceCache[cacheKey] = serviceCacheItem{
+			service: service,
+			expire:  time.Now().Add(time.Duration(crud.AccessCacheExpire) * time.Second),
+		}
+		serviceCacheMutex.Unlock()
+	}
+	return service, nil
+}
+
+// DeactivateUser method deactivates the user, removes the user sessions and the cached access information
+func (crud *Crud) DeactivateUser(userId string) mcresponse.ResponseMessage {
+	if userId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "userId is required",
+			Value:   nil,
+		})
+	}
+	if adminRes := crud.CheckAdminAccess(); adminRes.Code != "success" {
+		return adminRes
+	}
+	tx, err := crud.AccessDb.Beginx()
+	if err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deactivating user: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	userScript := fmt.Sprintf("UPDATE %v SET is_active=$1, updated_at=$2 WHERE id=$3", crud.UserTable)
+	sessionScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1", crud.AccessTable)
+	if _, err = tx.Exec(userScript, false, time.Now(), userId); err == nil {
+		_, err = tx.Exec(sessionScript, userId)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deactivating user: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if err = tx.Commit(); err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deactivating user: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	InvalidateUserAccessCache(userId)
+	// perform audit-log
+	if crud.LogUpdate || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(UpdateLog, crud.UserInfo.UserId, AuditLogOptionsType{
+			TableName:     crud.UserTable,
+			LogRecords:    map[string]interface{}{"id": userId, "isActive": true},
+			NewLogRecords: map[string]interface{}{"id": userId, "isActive": false},
+			RecordIds:     []string{userId},
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "User deactivated successfully",
+		Value:   userId,
+	})
+}
+
+// GetCachedRoleServices method returns the (cached, if AccessCache) role-services of the user role, for the
+// specified service items
+func (crud *Crud) GetCachedRoleServices(userId string, roleId string, serviceIds []string) ([]RoleServiceType, error) {
+	if !crud.AccessCache {
+		return crud.GetRoleServices(crud.AccessDb, crud.RoleTable, roleId, serviceIds)
+	}
+	cacheKey := crud.roleCacheKey(userId, []string{roleId}, serviceIds)
+	roleCacheMutex.RLock()
+	cacheItem, ok := roleCache[cacheKey]
+	roleCacheMutex.RUnlock()
+	if ok && time.Now().Before(cacheItem.expire) {
+		return cacheItem.roleServices, nil
+	}
+	roleServices, err := crud.GetRoleServices(crud.AccessDb, crud.RoleTable, roleId, serviceIds)
+	if err != nil {
+		return nil, err
+	}
+	roleCacheMutex.Lock()
+	roleCache[cacheKey] = roleCacheItem{
+		roleServices: roleServices,
+		expire:       time.Now().Add(time.Duration(crud.AccessCacheExpire) * time.Second),
+	}
+	roleCacheMutex.Unlock()
+	return roleServices, nil
+}
+
+// InvalidateAccessTablesCache method removes the cached access information, for the changes (save/delete) of
+// the user, role, role-inheritance and service tables records
+func (crud *Crud) InvalidateAccessTablesCache() {
+	crud.invalidateAccessTableCache(crud.TableName, crud.RecordIds)
+}
+
+// accessTablesWrite method removes the cached access information, of the successful (save/delete) write response
+// of the access tables, if AccessCache, and returns the write response
+func (crud *Crud) accessTablesWrite(res mcresponse.ResponseMessage) mcresponse.ResponseMessage {
+	if crud.AccessCache && res.Code == "success" {
+		crud.InvalidateAccessTablesCache()
+	}
+	return res
+}
+
+// invalidateAccessTableCache method removes the cached access information, for the changes of the access table
+// (user, role, role-inheritance or service table) records
+func (crud *Crud) invalidateAccessTableCache(tableName string, recordIds []string) {
+	switch tableName {
+	case crud.UserTable:
+		if len(recordIds) < 1 {
+			ClearAccessCache()
+			return
+		}
+		for _, userId := range recordIds {
+			InvalidateUserAccessCache(userId)
+		}
+	case crud.RoleTable, crud.RoleInheritTable:
+		ClearRoleCache()
+	case crud.ServiceTable:
+		ClearAccessCache()
+	}
+}
@@ -0,0 +1,87 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: access-context cache test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestAccessCache(t *testing.T) {
+	userInfo := UserInfoType{UserId: "user-1", LoginName: "abbeymart", Token: "token-1"}
+	accessInfo := AccessInfoType{UserId: "user-1", RoleId: "staff", IsActive: true}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should bound the cache expire by the token expire:",
+		TestFunc: func() {
+			now := time.Now()
+			tokenExpire := now.Add(10 * time.Second)
+			expire := AccessCacheExpireTime(now, 60, tokenExpire.UnixNano()/int64(time.Millisecond))
+			mctest.AssertEquals(t, expire.Before(now.Add(11*time.Second)), true, "cache expire should be the token expire")
+			expire = AccessCacheExpireTime(now, 60, 0)
+			mctest.AssertEquals(t, expire.Equal(now.Add(60*time.Second)), true, "cache expire should be the cache expire")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should cache and invalidate the access information, by token:",
+		TestFunc: func() {
+			SetAccessCache("test-auth", userInfo, accessInfo, 60)
+			cached, ok := GetAccessCache("test-auth", userInfo)
+			mctest.AssertEquals(t, ok, true, "cached access information expected")
+			mctest.AssertEquals(t, cached.RoleId, "staff", "cached roleId expected")
+			_, ok = GetAccessCache("test-auth", UserInfoType{UserId: "user-1", LoginName: "abbeymart", Token: "token-2"})
+			mctest.AssertEquals(t, ok, false, "no cached access information expected for another token")
+			InvalidateAccessCache("token-1")
+			_, ok = GetAccessCache("test-auth", userInfo)
+			mctest.AssertEquals(t, ok, false, "access information should be invalidated")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should not cache inactive or expired-token access information, and invalidate by user:",
+		TestFunc: func() {
+			inactive := accessInfo
+			inactive.IsActive = false
+			SetAccessCache("test-auth", userInfo, inactive, 60)
+			_, ok := GetAccessCache("test-auth", userInfo)
+			mctest.AssertEquals(t, ok, false, "inactive user should not be cached")
+			expired := accessInfo
+			expired.Expire = time.Now().Add(-time.Second).UnixNano() / int64(time.Millisecond)
+			SetAccessCache("test-auth", userInfo, expired, 60)
+			_, ok = GetAccessCache("test-auth", userInfo)
+			mctest.AssertEquals(t, ok, false, "expired token should not be cached")
+			SetAccessCache("test-auth", userInfo, accessInfo, 60)
+			InvalidateUserAccessCache("user-1")
+			_, ok = GetAccessCache("test-auth", userInfo)
+			mctest.AssertEquals(t, ok, false, "user access information should be invalidated")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should scope the cached access information by the authenticator:",
+		TestFunc: func() {
+			secretA := []byte("secret-a")
+			token, _ := SignJwtToken(map[string]interface{}{"sub": "user-9", "exp": time.Now().Add(time.Hour).Unix()}, secretA, "HS256")
+			jwtUser := UserInfoType{UserId: "user-9", Token: token}
+			newJwtCrud := func(secret []byte) Crud {
+				return Crud{
+					CrudParamsType:  CrudParamsType{UserInfo: jwtUser},
+					CrudOptionsType: CrudOptionsType{Authenticator: NewJwtAuthenticator(secret, JwtAuthenticator{}), AccessCache: true, AccessCacheExpire: 60},
+				}
+			}
+			cruds := []Crud{newJwtCrud(secretA), newJwtCrud([]byte("secret-b"))}
+			mctest.AssertEquals(t, cruds[0].CheckUserAccess().Code, "success", "token of the secret-a authenticator expected")
+			mctest.AssertEquals(t, cruds[1].CheckUserAccess().Code, "unAuthorized", "cached token of the secret-a authenticator, denied by the secret-b authenticator, expected")
+			dbCrud := Crud{CrudOptionsType: CrudOptionsType{AccessTable: "accesses", UserTable: "users"}}
+			otherDbCrud := Crud{CrudOptionsType: CrudOptionsType{AccessTable: "accesses", UserTable: "members"}}
+			mctest.AssertNotEquals(t, dbCrud.accessCacheScope(), otherDbCrud.accessCacheScope(), "access-db scopes, of the user tables, expected")
+			InvalidateUserAccessCache("user-9")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,105 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: access decision explanation and denied-access audit trail
+
+package mccrud
+
+import (
+	"time"
+)
+
+// AnonymousUserId is the audit-log user id of the unauthenticated access denials
+const AnonymousUserId = "anonymous"
+
+// AccessRules, the rule that granted or denied the access
+const (
+	AccessRuleAuthentication = "authentication"      // user token/login validation
+	AccessRuleInactive       = "inactive-user"       // account not active
+	AccessRuleService        = "service"             // table/collection not registered in the service table
+	AccessRuleAdmin          = "admin"               // admin user, all tasks permitted
+	AccessRuleOwner          = "owner"               // user created all the records
+	AccessRuleTable          = "table-role-service"  // role-service permission on the table/collection
+	AccessRuleRecord         = "record-role-service" // role-service permission on all the records
+	AccessRuleNoRoleService  = "no-role-service"     // no (matching) role-service permission
+	AccessRuleUnknownTask    = "unknown-task"        // unknown or unspecified task type
+	AccessRuleFieldWrite     = "field-write"         // field/column-level write permission
+	AccessRuleFieldRead      = "field-read"          // field/column-level read permission, of the query/sort params
+	AccessRulePolicy         = "policy"              // attribute-based access policy
+	AccessRuleShare          = "share"               // records shared with the user or the user groups
+)
+
+// AccessDecisionType is the structured explanation of an access decision
+type AccessDecisionType struct {
+	Allowed          bool              `json:"allowed"`
+	TaskType         string            `json:"taskType"`
+	TableName        string            `json:"tableName"`
+	UserId           string            `json:"userId"`
+	RoleId           string            `json:"roleId"`
+	RoleIds          []string          `json:"roleIds"` // roles considered
+	IsAdmin          bool              `json:"isAdmin"`
+	IsActive         bool              `json:"isActive"`
+	RoleServices     []RoleServiceType `json:"roleServices"` // matched role-services
+	OwnerPermitted   bool              `json:"ownerPermitted"`
+	Rule             string            `json:"rule"`
+	RuleId           string            `json:"ruleId"` // policy id, for the policy rule
+	Reason           string            `json:"reason"`
+	RecordIds        []string          `json:"recordIds"`
+	MissingRecordIds []string          `json:"missingRecordIds"` // records not owned or permitted
+	DecidedAt        time.Time         `json:"decidedAt"`
+	LogError         string            `json:"logError"` // denial audit-log error, if any
+}
+
+// MissingRecordIds function returns the recordIds not included in the permittedIds
+func MissingRecordIds(recordIds []string, permittedIds []string) []string {
+	var missingIds []string
+	for _, id := range recordIds {
+		if !ArrayStringContains(permittedIds, id) {
+			missingIds = append(missingIds, id)
+		}
+	}
+	return missingIds
+}
+
+// RecordAccessDecision method completes and records the access decision (crud.AccessDecision), and persists the
+// denied access decision through the audit logger (DenyLog), if LogAccessDenial. The unauthenticated denials are
+// logged by the AnonymousUserId, as the user id is not verified. The audit-log error is recorded (LogError).
+func (crud *Crud) RecordAccessDecision(decision AccessDecisionType) AccessDecisionType {
+	if decision.TaskType == "" {
+		decision.TaskType = crud.TaskType
+	}
+	if decision.TableName == "" {
+		decision.TableName = crud.TableName
+	}
+	if decision.UserId == "" {
+		decision.UserId = crud.UserInfo.UserId
+	}
+	if decision.RecordIds == nil {
+		decision.RecordIds = crud.RecordIds
+	}
+	decision.DecidedAt = time.Now()
+	if !decision.Allowed && crud.LogAccessDenial {
+		logBy := decision.UserId
+		if logBy == "" || decision.Rule == AccessRuleAuthentication {
+			logBy = AnonymousUserId
+		}
+		// denial audit-log errors should not change the access response
+		if _, err := crud.TransLog.AuditLog(DenyLog, logBy, AuditLogOptionsType{
+			TableName:  decision.TableName,
+			LogRecords: decision,
+			RecordIds:  decision.RecordIds,
+		}); err != nil {
+			decision.LogError = err.Error()
+		}
+	}
+	crud.AccessDecision = decision
+	return decision
+}
+
+// RoleServiceIds function returns the service ids of the role-services
+func RoleServiceIds(roleServices []RoleServiceType) []string {
+	var serviceIds []string
+	for _, rs := range roleServices {
+		serviceIds = append(serviceIds, rs.ServiceId)
+	}
+	return serviceIds
+}
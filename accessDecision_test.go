@@ -0,0 +1,79 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: access decision and denied-access audit trail test cases
+
+package mccrud
+
+import (
+	"errors"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+// failingAuditLogger fails all the audit-log writes
+type failingAuditLogger struct{}
+
+// AuditLog method returns the write error
+func (logger failingAuditLogger) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	return mcresponse.ResponseMessage{Code: "logError"}, errors.New("audit db unavailable")
+}
+
+func TestAccessDecision(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should log the unauthenticated denials by the anonymous user id:",
+		TestFunc: func() {
+			sink := NewMemoryAuditSink()
+			crud := Crud{CrudParamsType: CrudParamsType{TableName: "groups", TaskType: ReadTask}, CrudOptionsType: CrudOptionsType{LogAccessDenial: true}, TransLog: sink}
+			decision := crud.RecordAccessDecision(AccessDecisionType{Rule: AccessRuleAuthentication, Reason: "invalid token"})
+			mctest.AssertEquals(t, decision.LogError, "", "no denial audit-log error expected")
+			// the unverified (claimed) user id is recorded, but not logged as the denial user
+			crud.UserInfo.UserId = "user-1"
+			crud.RecordAccessDecision(AccessDecisionType{Rule: AccessRuleAuthentication, Reason: "expired token"})
+			entries := sink.Entries()
+			mctest.AssertEquals(t, len(entries), 2, "two denial entries expected")
+			mctest.AssertEquals(t, entries[0].LogBy, AnonymousUserId, "anonymous denial user expected")
+			mctest.AssertEquals(t, entries[0].LogType, DenyLog, "deny log type expected")
+			mctest.AssertEquals(t, entries[1].LogBy, AnonymousUserId, "anonymous denial user, of the claimed user id, expected")
+			mctest.AssertEquals(t, entries[1].LogRecords.(AccessDecisionType).UserId, "user-1", "claimed user id of the decision expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should log the authenticated denials only, by the user id:",
+		TestFunc: func() {
+			sink := NewMemoryAuditSink()
+			crud := Crud{CrudParamsType: CrudParamsType{TableName: "groups", UserInfo: UserInfoType{UserId: "user-1"}}, CrudOptionsType: CrudOptionsType{LogAccessDenial: true}, TransLog: sink}
+			crud.RecordAccessDecision(AccessDecisionType{Allowed: true, Rule: AccessRuleTable})
+			crud.RecordAccessDecision(AccessDecisionType{Rule: AccessRuleNoRoleService, RecordIds: []string{"g-1"}})
+			entries := sink.Entries()
+			mctest.AssertEquals(t, len(entries), 1, "one denial entry expected")
+			mctest.AssertEquals(t, entries[0].LogBy, "user-1", "denial user expected")
+			mctest.AssertEquals(t, entries[0].RecordIds, []string{"g-1"}, "denied record ids expected")
+			crud.LogAccessDenial = false
+			crud.RecordAccessDecision(AccessDecisionType{Rule: AccessRuleNoRoleService})
+			mctest.AssertEquals(t, len(sink.Entries()), 1, "no denial entry expected, without LogAccessDenial")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should record the denial audit-log error, without changing the decision:",
+		TestFunc: func() {
+			crud := Crud{CrudParamsType: CrudParamsType{TableName: "groups"}, CrudOptionsType: CrudOptionsType{LogAccessDenial: true}, TransLog: failingAuditLogger{}}
+			decision := crud.RecordAccessDecision(AccessDecisionType{Rule: AccessRuleAuthentication})
+			mctest.AssertEquals(t, decision.Allowed, false, "denied decision expected")
+			mctest.AssertEquals(t, decision.LogError, "audit db unavailable", "denial audit-log error expected")
+			mctest.AssertEquals(t, crud.AccessDecision.LogError, "audit db unavailable", "recorded denial audit-log error expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the missing record ids:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, MissingRecordIds([]string{"g-1", "g-2", "g-3"}, []string{"g-2"}), []string{"g-1", "g-3"}, "missing record ids expected")
+			mctest.AssertEquals(t, MissingRecordIds([]string{"g-1"}, []string{"g-1"}), []string(nil), "no missing record ids expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
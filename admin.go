@@ -0,0 +1,366 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: administrative methods for the services, roles and role-services (grants) tables
+
+package mccrud
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"strings"
+)
+
+// Service categories
+const (
+	ServiceCategoryTable      = "table"
+	ServiceCategoryCollection = "collection"
+	ServiceCategoryRecord     = "record"
+)
+
+// ServiceType is the service item (table/collection or record), from the service table
+type ServiceType struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// RoleType is the role definition, from the role-definition table
+type RoleType struct {
+	Id            string   `json:"id"`
+	Name          string   `json:"name"`
+	Description   string   `json:"description"`
+	ParentRoleIds []string `json:"parentRoleIds"` // inherited roles, requires RoleHierarchy
+	IsActive      bool     `json:"isActive"`
+}
+
+// GrantParamsType is the role-service grant (can_* permissions) of a role, on a table or record service
+type GrantParamsType struct {
+	RoleId           string                `json:"roleId"`
+	ServiceId        string                `json:"serviceId"`
+	ServiceCategory  string                `json:"serviceCategory"` // default: the service table category
+	CanRead          bool                  `json:"canRead"`
+	CanCreate        bool                  `json:"canCreate"`
+	CanUpdate        bool                  `json:"canUpdate"`
+	CanDelete        bool                  `json:"canDelete"`
+	CanCrud          bool                  `json:"canCrud"`
+	IsDeny           bool                  `json:"isDeny"`           // requires RoleHierarchy
+	FieldPermissions []FieldPermissionType `json:"fieldPermissions"` // requires CheckFieldAccess
+}
+
+// UserPermissionType is the effective permissions (role-services) of a user
+type UserPermissionType struct {
+	UserId           string            `json:"userId"`
+	RoleId           string            `json:"roleId"`
+	RoleIds          []string          `json:"roleIds"`
+	EffectiveRoleIds []string          `json:"effectiveRoleIds"` // including the inherited roles
+	IsAdmin          bool              `json:"isAdmin"`
+	RoleServices     []RoleServiceType `json:"roleServices"`
+}
+
+// CheckAdminAccess method determines if the current user is an active admin user
+func (crud *Crud) CheckAdminAccess() mcresponse.ResponseMessage {
+	accessRes := crud.CheckUserAccess()
+	if accessRes.Code != "success" {
+		return accessRes
+	}
+	accessInfo, ok := accessRes.Value.(AccessInfoType)
+	if !ok || !accessInfo.IsActive || !accessInfo.IsAdmin {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Only active admin users can perform the requested administrative action/task",
+			Value:   nil,
+		})
+	}
+	return accessRes
+}
+
+// SaveService method returns the registered service by name, or registers (inserts) the new service
+func (crud *Crud) SaveService(name string, category string) (ServiceType, error) {
+	service := ServiceType{Name: name, Category: category}
+	if name == "" || category == "" {
+		return service, errors.New("service name and category are required")
+	}
+	serviceScript := fmt.Sprintf("SELECT id, category from %v WHERE name=$1", crud.ServiceTable)
+	err := crud.AccessDb.QueryRow(serviceScript, name).Scan(&service.Id, &service.Category)
+	if err == nil {
+		return service, nil
+	}
+	if err != sql.ErrNoRows {
+		return service, err
+	}
+	insertScript := fmt.Sprintf("INSERT INTO %v(name, category) VALUES ($1, $2) RETURNING id", crud.ServiceTable)
+	if err = crud.AccessDb.QueryRow(insertScript, name, category).Scan(&service.Id); err != nil {
+		return service, err
+	}
+	crud.invalidateAccessTableCache(crud.ServiceTable, nil)
+	// perform audit-log
+	if crud.LogCreate || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(CreateLog, crud.UserInfo.UserId, AuditLogOptionsType{
+			TableName:  crud.ServiceTable,
+			LogRecords: service,
+			RecordIds:  []string{service.Id},
+		})
+	}
+	return service, nil
+}
+
+// RegisterService method registers the service (table/collection or record), for the role-services grants
+func (crud *Crud) RegisterService(name string, category string) mcresponse.ResponseMessage {
+	if adminRes := crud.CheckAdminAccess(); adminRes.Code != "success" {
+		return adminRes
+	}
+	service, err := crud.SaveService(name, category)
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error registering service %v: %v", name, err.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Service %v registered successfully", name),
+		Value:   service,
+	})
+}
+
+// CreateRole method creates the role definition, and the inherited (parent) roles
+func (crud *Crud) CreateRole(role RoleType) mcresponse.ResponseMessage {
+	if role.Name == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "role name is required",
+			Value:   nil,
+		})
+	}
+	if adminRes := crud.CheckAdminAccess(); adminRes.Code != "success" {
+		return adminRes
+	}
+	tx, err := crud.AccessDb.Beginx()
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error starting role transaction: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	roleScript := fmt.Sprintf("INSERT INTO %v(name, description, is_active) VALUES ($1, $2, $3) RETURNING id", crud.RoleDefinitionTable)
+	err = tx.QueryRow(roleScript, role.Name, role.Description, role.IsActive).Scan(&role.Id)
+	if err == nil {
+		inheritScript := fmt.Sprintf("INSERT INTO %v(role_id, parent_role_id) VALUES ($1, $2)", crud.RoleInheritTable)
+		for _, parentId := range role.ParentRoleIds {
+			if _, err = tx.Exec(inheritScript, role.Id, parentId); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating role %v: %v", role.Name, err.Error()),
+			Value:   nil,
+		})
+	}
+	if err = tx.Commit(); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating role %v: %v", role.Name, err.Error()),
+			Value:   nil,
+		})
+	}
+	// role inheritance changed, for the inheriting roles
+	if len(role.ParentRoleIds) > 0 {
+		crud.invalidateAccessTableCache(crud.RoleInheritTable, nil)
+	}
+	// perform audit-log
+	if crud.LogCreate || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(CreateLog, crud.UserInfo.UserId, AuditLogOptionsType{
+			TableName:  crud.RoleDefinitionTable,
+			LogRecords: role,
+			RecordIds:  []string{role.Id},
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Role %v created successfully", role.Name),
+		Value:   role,
+	})
+}
+
+// GrantRoleService method grants the CRUD permissions of the role on the service (table or record), replacing
+// the existing grant
+func (crud *Crud) GrantRoleService(params GrantParamsType) mcresponse.ResponseMessage {
+	if params.RoleId == "" || params.ServiceId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "roleId and serviceId are required",
+			Value:   nil,
+		})
+	}
+	if adminRes := crud.CheckAdminAccess(); adminRes.Code != "success" {
+		return adminRes
+	}
+	if params.ServiceCategory == "" {
+		serviceScript := fmt.Sprintf("SELECT category from %v WHERE id=$1", crud.ServiceTable)
+		if err := crud.AccessDb.QueryRow(serviceScript, params.ServiceId).Scan(&params.ServiceCategory); err != nil {
+			return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Service %v not found: %v", params.ServiceId, err.Error()),
+				Value:   nil,
+			})
+		}
+	}
+	fields := "role_id, service_id, service_category, can_read, can_create, can_update, can_delete, can_crud, is_active"
+	values := []interface{}{params.RoleId, params.ServiceId, params.ServiceCategory, params.CanRead, params.CanCreate, params.CanUpdate, params.CanDelete, params.CanCrud, true}
+	if crud.CheckFieldAccess {
+		fieldPerms, _ := json.Marshal(params.FieldPermissions)
+		fields += ", field_permissions"
+		values = append(values, string(fieldPerms))
+	}
+	if crud.RoleHierarchy {
+		fields += ", is_deny"
+		values = append(values, params.IsDeny)
+	}
+	var placeholders []string
+	for i := range values {
+		placeholders = append(placeholders, fmt.Sprintf("$%v", i+1))
+	}
+	tx, err := crud.AccessDb.Beginx()
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error starting grant transaction: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE role_id=$1 AND service_id=$2", crud.RoleTable)
+	insertScript := fmt.Sprintf("INSERT INTO %v(%v) VALUES (%v)", crud.RoleTable, fields, strings.Join(placeholders, ", "))
+	if _, err = tx.Exec(delScript, params.RoleId, params.ServiceId); err == nil {
+		_, err = tx.Exec(insertScript, values...)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error granting role-service: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if err = tx.Commit(); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error granting role-service: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// role-services changed, for all the users of the role (and the inheriting roles)
+	crud.invalidateAccessTableCache(crud.RoleTable, nil)
+	// perform audit-log
+	if crud.LogCreate || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(CreateLog, crud.UserInfo.UserId, AuditLogOptionsType{
+			TableName:  crud.RoleTable,
+			LogRecords: params,
+			RecordIds:  []string{params.ServiceId},
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Role %v permissions on service %v granted successfully", params.RoleId, params.ServiceId),
+		Value:   params,
+	})
+}
+
+// RevokeRoleService method revokes all the permissions of the role on the service (table or record)
+func (crud *Crud) RevokeRoleService(roleId string, serviceId string) mcresponse.ResponseMessage {
+	if roleId == "" || serviceId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "roleId and serviceId are required",
+			Value:   nil,
+		})
+	}
+	if adminRes := crud.CheckAdminAccess(); adminRes.Code != "success" {
+		return adminRes
+	}
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE role_id=$1 AND service_id=$2", crud.RoleTable)
+	res, err := crud.AccessDb.Exec(delScript, roleId, serviceId)
+	if err != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error revoking role-service: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	rowsCount, _ := res.RowsAffected()
+	// role-services changed, for all the users of the role (and the inheriting roles)
+	crud.invalidateAccessTableCache(crud.RoleTable, nil)
+	// perform audit-log
+	if crud.LogDelete || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(DeleteLog, crud.UserInfo.UserId, AuditLogOptionsType{
+			TableName:  crud.RoleTable,
+			LogRecords: map[string]interface{}{"roleId": roleId, "serviceId": serviceId},
+			RecordIds:  []string{serviceId},
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v role-service(s) revoked successfully", rowsCount),
+		Value:   rowsCount,
+	})
+}
+
+// ListUserPermissions method returns the effective permissions (role-services) of the user, for the admin or
+// the current user
+func (crud *Crud) ListUserPermissions(userId string) mcresponse.ResponseMessage {
+	if userId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "userId is required",
+			Value:   nil,
+		})
+	}
+	accessRes := crud.CheckUserAccess()
+	if accessRes.Code != "success" {
+		return accessRes
+	}
+	if accessInfo, ok := accessRes.Value.(AccessInfoType); !ok || (userId != accessInfo.UserId && !accessInfo.IsAdmin) {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "You are not authorized to list the permissions of other users",
+			Value:   nil,
+		})
+	}
+	userRes := GetUserAccessInfo(crud.AccessDb, crud.UserTable, userId)
+	if userRes.Code != "success" {
+		return userRes
+	}
+	userInfo, _ := userRes.Value.(AccessInfoType)
+	permissions := UserPermissionType{
+		UserId:  userInfo.UserId,
+		RoleId:  userInfo.RoleId,
+		RoleIds: userInfo.RoleIds,
+		IsAdmin: userInfo.IsAdmin,
+	}
+	for _, roleId := range append([]string{userInfo.RoleId}, userInfo.RoleIds...) {
+		if roleId != "" && !ArrayStringContains(permissions.EffectiveRoleIds, roleId) {
+			permissions.EffectiveRoleIds = append(permissions.EffectiveRoleIds, roleId)
+		}
+	}
+	if len(permissions.EffectiveRoleIds) > 0 {
+		var err error
+		if crud.RoleHierarchy {
+			var roleParents map[string][]string
+			if roleParents, err = crud.GetRoleParents(crud.AccessDb, crud.RoleInheritTable, permissions.EffectiveRoleIds); err == nil {
+				permissions.EffectiveRoleIds, err = ResolveRoleHierarchy(permissions.EffectiveRoleIds, roleParents)
+			}
+		}
+		if err == nil {
+			permissions.RoleServices, err = crud.GetRoleServicesByRoleIds(crud.AccessDb, crud.RoleTable, permissions.EffectiveRoleIds, nil)
+		}
+		if err != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading user permissions: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		if crud.RoleHierarchy {
+			permissions.RoleServices = MergeRoleServices(permissions.RoleServices)
+		}
+	}
+	// perform audit-log
+	if crud.LogRead || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(ReadLog, crud.UserInfo.UserId, AuditLogOptionsType{
+			TableName:  crud.RoleTable,
+			LogRecords: map[string]interface{}{"userId": userId},
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v effective role-service(s) found", len(permissions.RoleServices)),
+		Value:   permissions,
+	})
+}
@@ -0,0 +1,127 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: administrative (services, roles and role-services) methods test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestAdmin(t *testing.T) {
+	secret := []byte("test-secret")
+	auth := NewJwtAuthenticator(secret, JwtAuthenticator{})
+	userToken := func(userId string, isAdmin bool) UserInfoType {
+		token, _ := SignJwtToken(map[string]interface{}{
+			"sub":     userId,
+			"isAdmin": isAdmin,
+			"exp":     time.Now().Add(time.Hour).Unix(),
+		}, secret, "HS256")
+		return UserInfoType{UserId: userId, Token: token}
+	}
+	newAdminCrud := func(userInfo UserInfoType) Crud {
+		return Crud{
+			CrudParamsType: CrudParamsType{UserInfo: userInfo},
+			CrudOptionsType: CrudOptionsType{Authenticator: auth, UserTable: "users", RoleTable: "roles",
+				RoleInheritTable: "role_inherits", ServiceTable: "services", AccessCache: true, AccessCacheExpire: 60},
+		}
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should require the admin users, for the administrative tasks:",
+		TestFunc: func() {
+			crud := newAdminCrud(userToken("user-1", false))
+			mctest.AssertEquals(t, crud.CheckAdminAccess().Code, "unAuthorized", "non-admin user denied")
+			mctest.AssertEquals(t, crud.RegisterService("groups", ServiceCategoryTable).Code, "unAuthorized", "non-admin service registration denied")
+			mctest.AssertEquals(t, crud.CreateRole(RoleType{Name: "staff"}).Code, "unAuthorized", "non-admin role creation denied")
+			mctest.AssertEquals(t, crud.GrantRoleService(GrantParamsType{RoleId: "staff", ServiceId: "s-1", CanRead: true}).Code, "unAuthorized", "non-admin grant denied")
+			mctest.AssertEquals(t, crud.RevokeRoleService("staff", "s-1").Code, "unAuthorized", "non-admin revoke denied")
+			crud.UserInfo = UserInfoType{UserId: "admin-1", Token: "invalid-token"}
+			mctest.AssertEquals(t, crud.CheckAdminAccess().Code, "unAuthorized", "unauthenticated (claimed) admin denied")
+			crud.UserInfo = userToken("admin-1", true)
+			mctest.AssertEquals(t, crud.CheckAdminAccess().Code, "success", "admin user permitted")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should require the grant and revoke params:",
+		TestFunc: func() {
+			crud := newAdminCrud(userToken("admin-1", true))
+			mctest.AssertEquals(t, crud.GrantRoleService(GrantParamsType{RoleId: "staff"}).Code, "paramsError", "grant serviceId required")
+			mctest.AssertEquals(t, crud.RevokeRoleService("", "s-1").Code, "paramsError", "revoke roleId required")
+			mctest.AssertEquals(t, crud.CreateRole(RoleType{}).Code, "paramsError", "role name required")
+			mctest.AssertEquals(t, crud.ListUserPermissions("").Code, "paramsError", "permissions userId required")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should invalidate the cached access information, of the changed access tables:",
+		TestFunc: func() {
+			crud := newAdminCrud(userToken("admin-1", true))
+			userInfo := UserInfoType{UserId: "user-1", LoginName: "abbeymart", Token: "token-1"}
+			cacheRole := func() {
+				roleCacheMutex.Lock()
+				roleCache[crud.roleCacheKey("user-1", []string{"staff"}, []string{"s-1"})] = roleCacheItem{expire: time.Now().Add(time.Minute)}
+				roleCacheMutex.Unlock()
+			}
+			roleCached := func() bool {
+				roleCacheMutex.RLock()
+				defer roleCacheMutex.RUnlock()
+				_, ok := roleCache[crud.roleCacheKey("user-1", []string{"staff"}, []string{"s-1"})]
+				return ok
+			}
+			// role-services grants (role table) changes
+			cacheRole()
+			SetAccessCache("test-auth", userInfo, AccessInfoType{UserId: "user-1", IsActive: true}, 60)
+			crud.invalidateAccessTableCache(crud.RoleTable, nil)
+			mctest.AssertEquals(t, roleCached(), false, "cached role-services invalidated, of the role table")
+			_, ok := GetAccessCache("test-auth", userInfo)
+			mctest.AssertEquals(t, ok, true, "cached access information retained, of the role table")
+			// role inheritance changes
+			cacheRole()
+			crud.invalidateAccessTableCache(crud.RoleInheritTable, nil)
+			mctest.AssertEquals(t, roleCached(), false, "cached role-services invalidated, of the role-inheritance table")
+			// services changes
+			serviceCacheMutex.Lock()
+			serviceCache["services|groups"] = serviceCacheItem{service: ServiceType{Id: "s-1", Name: "groups"}, expire: time.Now().Add(time.Minute)}
+			serviceCacheMutex.Unlock()
+			crud.invalidateAccessTableCache(crud.ServiceTable, nil)
+			serviceCacheMutex.RLock()
+			_, ok = serviceCache["services|groups"]
+			serviceCacheMutex.RUnlock()
+			mctest.AssertEquals(t, ok, false, "cached services invalidated, of the service table")
+			// users changes, by the crud table and record ids
+			SetAccessCache("test-auth", userInfo, AccessInfoType{UserId: "user-1", IsActive: true}, 60)
+			crud.TableName = crud.UserTable
+			crud.RecordIds = []string{"user-1"}
+			crud.InvalidateAccessTablesCache()
+			_, ok = GetAccessCache("test-auth", userInfo)
+			mctest.AssertEquals(t, ok, false, "cached access information invalidated, of the user")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should invalidate the cached access information, of the successful access-table writes only:",
+		TestFunc: func() {
+			crud := newAdminCrud(userToken("admin-1", true))
+			crud.TableName = crud.UserTable
+			crud.RecordIds = []string{"user-2"}
+			userInfo := UserInfoType{UserId: "user-2", LoginName: "abiola", Token: "token-2"}
+			SetAccessCache("test-auth", userInfo, AccessInfoType{UserId: "user-2", IsActive: true}, 60)
+			for _, code := range []string{"unAuthorized", "updateError", "deleteError"} {
+				res := crud.accessTablesWrite(mcresponse.GetResMessage(code, mcresponse.ResponseMessageOptions{}))
+				mctest.AssertEquals(t, res.Code, code, "write response expected")
+				_, ok := GetAccessCache("test-auth", userInfo)
+				mctest.AssertEquals(t, ok, true, "cached access information retained, of the "+code+" write")
+			}
+			crud.accessTablesWrite(mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}))
+			_, ok := GetAccessCache("test-auth", userInfo)
+			mctest.AssertEquals(t, ok, false, "cached access information invalidated, of the successful write")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,246 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: tamper-evident (hash-chained) audit-log entries and chain verification
+
+package mccrud
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+// hash-chain scopes and the global chain key
+const (
+	AuditChainTableScope  = "table"
+	AuditChainGlobalScope = "global"
+	AuditChainGlobalKey   = "*"
+)
+
+// hash-chain verification issues
+const (
+	AuditChainGap      = "gap"      // missing (deleted) entries
+	AuditChainSequence = "sequence" // duplicate or out-of-order entries
+	AuditChainLink     = "link"     // previous-hash mismatch: removed, reordered or inserted entries
+	AuditChainModified = "modified" // entry-hash mismatch: modified entry
+)
+
+// AuditChainEntryType is the hash-chained audit-log entry. The hash is computed from the entry content and the
+// previous entry hash, of the same chain (chain_key), by sequence (chain_seq).
+type AuditChainEntryType struct {
+	Id            string           `json:"id"`
+	ChainKey      string           `json:"chainKey"`
+	ChainSeq      int64            `json:"chainSeq"`
+	TableName     string           `json:"tableName"`
+	LogRecords    interface{}      `json:"logRecords"`
+	NewLogRecords interface{}      `json:"newLogRecords"`
+	LogDiff       interface{}      `json:"logDiff"`
+	LogType       string           `json:"logType"`
+	LogBy         string           `json:"logBy"`
+	LogAt         time.Time        `json:"logAt"`
+	Context       AuditContextType `json:"context"`
+	PrevHash      string           `json:"prevHash"`
+	Hash          string           `json:"hash"`
+}
+
+// AuditChainHeadType is the last sequence and hash of the chain, and the last archived (retention-deleted) sequence
+// and hash of the chain prefix, from the chain table
+type AuditChainHeadType struct {
+	ChainKey     string `json:"chainKey"`
+	ChainSeq     int64  `json:"chainSeq"`
+	Hash         string `json:"hash"`
+	ArchivedSeq  int64  `json:"archivedSeq"`
+	ArchivedHash string `json:"archivedHash"`
+}
+
+// AuditChainIssueType is a chain verification issue, by sequence
+type AuditChainIssueType struct {
+	ChainSeq int64  `json:"chainSeq"`
+	Id       string `json:"id"`
+	Issue    string `json:"issue"`
+	Message  string `json:"message"`
+}
+
+// AuditChainResultType is the VerifyAuditChain response value
+type AuditChainResultType struct {
+	ChainKey string                `json:"chainKey"`
+	Valid    bool                  `json:"valid"`
+	Count    int                   `json:"count"`
+	LastSeq  int64                 `json:"lastSeq"`
+	LastHash string                `json:"lastHash"`
+	Issues   []AuditChainIssueType `json:"issues"`
+}
+
+// AuditChainKey function returns the chain key of the table, for the chain scope
+func AuditChainKey(chainScope string, tableName string) string {
+	if chainScope == AuditChainGlobalScope {
+		return AuditChainGlobalKey
+	}
+	return tableName
+}
+
+// auditCanonicalJson returns the canonical (decoded and re-encoded) json of the audit-log payload, as the db
+// (e.g. jsonb) may not preserve the json keys order and spacing
+func auditCanonicalJson(val interface{}) string {
+	payload := DecodeAuditPayload(val)
+	if payload == nil {
+		return ""
+	}
+	jsonVal, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	return string(jsonVal)
+}
+
+// ComputeAuditHash function returns the sha256-hash (hex) of the audit-log entry content and previous hash
+func ComputeAuditHash(entry AuditChainEntryType) string {
+	contentValues := []interface{}{
+		entry.ChainKey,
+		entry.ChainSeq,
+		entry.TableName,
+		entry.LogType,
+		entry.LogBy,
+		entry.LogAt.UTC().Format(time.RFC3339Nano),
+		auditCanonicalJson(entry.LogRecords),
+		auditCanonicalJson(entry.NewLogRecords),
+		auditCanonicalJson(entry.LogDiff),
+		entry.PrevHash,
+	}
+	// the audit context, if specified
+	if !entry.Context.IsEmpty() {
+		contentValues = append(contentValues, entry.Context)
+	}
+	content, _ := json.Marshal(contentValues)
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:])
+}
+
+// chainTable returns the hash-chain heads table
+func (log LogParamX) chainTable() string {
+	return log.engine().chainTable()
+}
+
+// ChainLog method inserts the hash-chained audit-log entry, by the audit engine
+func (log LogParamX) ChainLog(entry AuditChainEntryType) (sql.Result, error) {
+	return log.engine().ChainLog(entry)
+}
+
+// VerifyAuditEntries function verifies the chain entries, ordered by sequence, and the chain head, from the archived
+// chain prefix (head ArchivedSeq and ArchivedHash). Missing, duplicate, reordered, inserted and modified entries are
+// reported as issues.
+func VerifyAuditEntries(head AuditChainHeadType, entries []AuditChainEntryType) AuditChainResultType {
+	result := AuditChainResultType{ChainKey: head.ChainKey, Count: len(entries)}
+	addIssue := func(chainSeq int64, id string, issue string, message string) {
+		result.Issues = append(result.Issues, AuditChainIssueType{
+			ChainSeq: chainSeq,
+			Id:       id,
+			Issue:    issue,
+			Message:  message,
+		})
+	}
+	expectedSeq := head.ArchivedSeq + 1
+	prevHash := head.ArchivedHash
+	for _, entry := range entries {
+		if entry.ChainSeq > expectedSeq {
+			addIssue(entry.ChainSeq, entry.Id, AuditChainGap, fmt.Sprintf("Missing audit-log entries, sequence %v to %v", expectedSeq, entry.ChainSeq-1))
+		} else if entry.ChainSeq < expectedSeq {
+			addIssue(entry.ChainSeq, entry.Id, AuditChainSequence, fmt.Sprintf("Duplicate or out-of-order audit-log entry, sequence %v, expected %v", entry.ChainSeq, expectedSeq))
+		}
+		if entry.PrevHash != prevHash {
+			addIssue(entry.ChainSeq, entry.Id, AuditChainLink, "Previous-hash mismatch: audit-log entries removed, reordered or inserted")
+		}
+		if ComputeAuditHash(entry) != entry.Hash {
+			addIssue(entry.ChainSeq, entry.Id, AuditChainModified, "Hash mismatch: audit-log entry modified")
+		}
+		prevHash = entry.Hash
+		if entry.ChainSeq >= expectedSeq {
+			expectedSeq = entry.ChainSeq + 1
+		}
+	}
+	result.LastSeq = expectedSeq - 1
+	result.LastHash = prevHash
+	// the chain head: removed (tail) or appended entries
+	if head.ChainSeq > result.LastSeq {
+		addIssue(head.ChainSeq, "", AuditChainGap, fmt.Sprintf("Missing audit-log entries, sequence %v to %v", result.LastSeq+1, head.ChainSeq))
+	} else if head.ChainSeq < result.LastSeq {
+		addIssue(result.LastSeq, "", AuditChainSequence, fmt.Sprintf("Audit-log entries beyond the chain head, sequence %v", head.ChainSeq))
+	} else if head.Hash != result.LastHash {
+		addIssue(head.ChainSeq, "", AuditChainLink, "Chain-head hash mismatch: last audit-log entry replaced")
+	}
+	result.Valid = len(result.Issues) == 0
+	return result
+}
+
+// VerifyAuditChain method verifies the hash-chained audit-log entries of the chain key (table name, or
+// AuditChainGlobalKey for the global scope), from the archived chain prefix up to the current chain head
+func (log LogParamX) VerifyAuditChain(chainKey string) mcresponse.ResponseMessage {
+	if chainKey == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "chain key (table name or global chain key) is required",
+			Value:   nil,
+		})
+	}
+	head := AuditChainHeadType{ChainKey: chainKey}
+	headScript := fmt.Sprintf("SELECT chain_seq, hash, archived_seq, archived_hash FROM %v WHERE chain_key=$1", log.chainTable())
+	if err := log.AuditDb.QueryRow(headScript, chainKey).Scan(&head.ChainSeq, &head.Hash, &head.ArchivedSeq, &head.ArchivedHash); err != nil && err != sql.ErrNoRows {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading audit-log chain: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// entries up to the chain head, committed with the head, for the concurrent writers
+	fields := "id, chain_key, chain_seq, table_name, log_records, new_log_records, log_type, log_by, log_at, prev_hash, hash"
+	if log.AuditDiff {
+		fields += ", log_diff"
+	}
+	if log.LogContext {
+		fields += ", " + strings.Join(auditContextFields, ", ")
+	}
+	entryScript := fmt.Sprintf("SELECT %v FROM %v WHERE chain_key=$1 AND chain_seq<=$2 ORDER BY chain_seq, id", fields, log.AuditTable)
+	rows, err := log.AuditDb.Queryx(entryScript, chainKey, head.ChainSeq)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading audit-log chain: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+	var entries []AuditChainEntryType
+	for rows.Next() {
+		var entry AuditChainEntryType
+		scanValues := []interface{}{&entry.Id, &entry.ChainKey, &entry.ChainSeq, &entry.TableName, &entry.LogRecords, &entry.NewLogRecords, &entry.LogType, &entry.LogBy, &entry.LogAt, &entry.PrevHash, &entry.Hash}
+		if log.AuditDiff {
+			scanValues = append(scanValues, &entry.LogDiff)
+		}
+		if log.LogContext {
+			scanValues = append(scanValues, entry.Context.scanValues()...)
+		}
+		if err = rows.Scan(scanValues...); err != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading audit-log chain: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		entries = append(entries, entry)
+	}
+	result := VerifyAuditEntries(head, entries)
+	if !result.Valid {
+		return mcresponse.GetResMessage("logError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Audit-log chain verification failed: %v issue(s)", len(result.Issues)),
+			Value:   result,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Audit-log chain verified: %v entries", result.Count),
+		Value:   result,
+	})
+}
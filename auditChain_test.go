@@ -0,0 +1,98 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: hash-chained audit-log verification test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+// testAuditChain returns the hash-chained entries and chain head, of the log records
+func testAuditChain(logRecords []string) ([]AuditChainEntryType, AuditChainHeadType) {
+	var entries []AuditChainEntryType
+	prevHash := ""
+	logAt := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	for i, logRecord := range logRecords {
+		entry := AuditChainEntryType{
+			Id:         logRecord,
+			ChainKey:   "groups",
+			ChainSeq:   int64(i + 1),
+			TableName:  "groups",
+			LogRecords: `{"id":"` + logRecord + `","name":"Group"}`,
+			LogType:    CreateLog,
+			LogBy:      "user-1",
+			LogAt:      logAt.Add(time.Duration(i) * time.Second),
+			PrevHash:   prevHash,
+		}
+		entry.Hash = ComputeAuditHash(entry)
+		prevHash = entry.Hash
+		entries = append(entries, entry)
+	}
+	return entries, AuditChainHeadType{ChainKey: "groups", ChainSeq: int64(len(entries)), Hash: prevHash}
+}
+
+// auditChainIssues returns the issue names of the verification result
+func auditChainIssues(result AuditChainResultType) []string {
+	var issues []string
+	for _, issue := range result.Issues {
+		issues = append(issues, issue.Issue)
+	}
+	return issues
+}
+
+func TestAuditChain(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should verify the valid chain, with the db (canonical) json payload:",
+		TestFunc: func() {
+			entries, head := testAuditChain([]string{"rec-1", "rec-2", "rec-3"})
+			// jsonb keys order and spacing
+			entries[1].LogRecords = []byte(`{"name": "Group", "id": "rec-2"}`)
+			result := VerifyAuditEntries(head, entries)
+			mctest.AssertEquals(t, result.Valid, true, "valid chain expected")
+			mctest.AssertEquals(t, result.LastSeq, int64(3), "last sequence expected")
+			mctest.AssertEquals(t, AuditChainKey(AuditChainGlobalScope, "groups"), AuditChainGlobalKey, "global chain key expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should detect the modified, removed and reordered entries:",
+		TestFunc: func() {
+			entries, head := testAuditChain([]string{"rec-1", "rec-2", "rec-3"})
+			entries[1].LogBy = "user-2"
+			mctest.AssertEquals(t, auditChainIssues(VerifyAuditEntries(head, entries)), []string{AuditChainModified}, "modified entry expected")
+
+			entries, head = testAuditChain([]string{"rec-1", "rec-2", "rec-3"})
+			removed := []AuditChainEntryType{entries[0], entries[2]}
+			mctest.AssertEquals(t, auditChainIssues(VerifyAuditEntries(head, removed)), []string{AuditChainGap, AuditChainLink}, "missing entry expected")
+			mctest.AssertEquals(t, auditChainIssues(VerifyAuditEntries(head, entries[:2])), []string{AuditChainGap}, "missing tail entry expected")
+
+			reordered := []AuditChainEntryType{entries[0], entries[2], entries[1]}
+			mctest.AssertEquals(t, auditChainIssues(VerifyAuditEntries(head, reordered)), []string{AuditChainGap, AuditChainLink, AuditChainSequence, AuditChainLink, AuditChainLink}, "reordered entries expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should verify the chain from the archived prefix anchor:",
+		TestFunc: func() {
+			entries, head := testAuditChain([]string{"rec-1", "rec-2", "rec-3", "rec-4"})
+			var records []map[string]interface{}
+			for _, entry := range entries[:2] {
+				records = append(records, map[string]interface{}{"chain_key": entry.ChainKey, "chain_seq": entry.ChainSeq, "hash": entry.Hash})
+			}
+			anchors := AuditChainAnchors(records)
+			mctest.AssertEquals(t, len(anchors), 1, "one chain anchor expected")
+			mctest.AssertEquals(t, auditChainIssues(VerifyAuditEntries(head, entries[2:])), []string{AuditChainGap, AuditChainLink}, "missing prefix expected, without the anchor")
+			head.ArchivedSeq = anchors[0].ArchivedSeq
+			head.ArchivedHash = anchors[0].ArchivedHash
+			result := VerifyAuditEntries(head, entries[2:])
+			mctest.AssertEquals(t, result.Valid, true, "valid chain expected, from the anchor")
+			mctest.AssertEquals(t, result.Count, 2, "retained entries count expected")
+			mctest.AssertEquals(t, auditChainIssues(VerifyAuditEntries(head, entries[3:])), []string{AuditChainGap, AuditChainLink}, "missing entry after the anchor expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
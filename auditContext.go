@@ -0,0 +1,85 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit context (request metadata and correlation ids) of the audit-log entries
+
+package mccrud
+
+import (
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+)
+
+// AuditContextType is the request metadata of the crud-task audit-log entries
+type AuditContextType struct {
+	RequestId     string `json:"requestId"`
+	CorrelationId string `json:"correlationId"`
+	ClientIp      string `json:"clientIp"`
+	UserAgent     string `json:"userAgent"`
+	AppId         string `json:"appId"`
+	Reason        string `json:"reason"`
+}
+
+// auditContextFields is the audit-table context columns, in the AuditContextType fields order
+var auditContextFields = []string{"request_id", "correlation_id", "client_ip", "user_agent", "app_id", "reason"}
+
+// IsEmpty method returns true, if no audit context value is specified
+func (ctx AuditContextType) IsEmpty() bool {
+	return ctx == AuditContextType{}
+}
+
+// values method returns the audit context values, in the auditContextFields order
+func (ctx AuditContextType) values() []interface{} {
+	return []interface{}{ctx.RequestId, ctx.CorrelationId, ctx.ClientIp, ctx.UserAgent, ctx.AppId, ctx.Reason}
+}
+
+// scanValues method returns the audit context fields pointers, in the auditContextFields order. The null values
+// (entries logged without the audit context) are scanned as empty strings.
+func (ctx *AuditContextType) scanValues() []interface{} {
+	return []interface{}{
+		(*nullString)(&ctx.RequestId),
+		(*nullString)(&ctx.CorrelationId),
+		(*nullString)(&ctx.ClientIp),
+		(*nullString)(&ctx.UserAgent),
+		(*nullString)(&ctx.AppId),
+		(*nullString)(&ctx.Reason),
+	}
+}
+
+// nullString scans the null or string db-value, as string
+type nullString string
+
+// Scan method implements the sql.Scanner interface
+func (s *nullString) Scan(val interface{}) error {
+	switch v := val.(type) {
+	case nil:
+		*s = ""
+	case []byte:
+		*s = nullString(v)
+	default:
+		*s = nullString(fmt.Sprintf("%v", v))
+	}
+	return nil
+}
+
+// auditContext method returns the crud audit context, app-id default: the crud app/tenant, in multi-tenant mode
+func (crud *Crud) auditContext() AuditContextType {
+	ctx := crud.AuditContext
+	if ctx.AppId == "" && crud.TenantMode {
+		ctx.AppId = crud.AppParams.AppId
+	}
+	return ctx
+}
+
+// contextAuditLogger sets the crud audit context, as of the log time, of the audit-log entries without an audit context
+type contextAuditLogger struct {
+	Logger AuditLogger
+	Crud   *Crud
+}
+
+// AuditLog method sets the audit context, and logs the entry by the logger
+func (logger contextAuditLogger) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	if options.AuditContext.IsEmpty() {
+		options.AuditContext = logger.Crud.auditContext()
+	}
+	return logger.Logger.AuditLog(logType, userId, options)
+}
@@ -0,0 +1,124 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: field-level change diffs (old -> new), by record id, for the update audit-log
+
+package mccrud
+
+import (
+	"encoding/json"
+	"github.com/asaskevich/govalidator"
+	"sort"
+)
+
+// FieldChangeType is the old and new values of a changed field
+type FieldChangeType struct {
+	Field    string      `json:"field"`
+	OldValue interface{} `json:"oldValue"`
+	NewValue interface{} `json:"newValue"`
+}
+
+// RecordDiffType is the changed fields of a record
+type RecordDiffType struct {
+	RecordId string            `json:"recordId"`
+	Changes  []FieldChangeType `json:"changes"`
+}
+
+// auditDiffValue returns the comparable (json) value of the field value, e.g. for int64 and float64 values
+func auditDiffValue(val interface{}) string {
+	jsonVal, err := json.Marshal(val)
+	if err != nil {
+		return ""
+	}
+	return string(jsonVal)
+}
+
+// ComputeRecordDiff function returns the changed fields (old -> new) of the record update. The unchanged fields
+// and the stamp fields (id, created/updated by/at) are skipped. Field names are matched as camelCase or underscore.
+func ComputeRecordDiff(recordId string, currentRecord map[string]interface{}, newRecord map[string]interface{}) RecordDiffType {
+	diff := RecordDiffType{RecordId: recordId}
+	currentFields := map[string]interface{}{}
+	for fieldName, val := range currentRecord {
+		currentFields[govalidator.CamelCaseToUnderscore(fieldName)] = val
+	}
+	var fieldNames []string
+	for fieldName := range newRecord {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		underscoreName := govalidator.CamelCaseToUnderscore(fieldName)
+		if ArrayStringContains(fieldWriteExempted, underscoreName) {
+			continue
+		}
+		oldValue := currentFields[underscoreName]
+		newValue := newRecord[fieldName]
+		if auditDiffValue(oldValue) == auditDiffValue(newValue) {
+			continue
+		}
+		diff.Changes = append(diff.Changes, FieldChangeType{
+			Field:    fieldName,
+			OldValue: oldValue,
+			NewValue: newValue,
+		})
+	}
+	return diff
+}
+
+// ComputeRecordDiffs function returns the diffs of the changed records. The new records (with id) are matched to
+// the current records by id, or the single new record (without id) applies to all the current records.
+func ComputeRecordDiffs(currentRecords []map[string]interface{}, newRecords ActionParamsType) []RecordDiffType {
+	var diffs []RecordDiffType
+	for _, currentRecord := range currentRecords {
+		recordId, _ := currentRecord["id"].(string)
+		var newRecord map[string]interface{}
+		if len(newRecords) == 1 {
+			if id, ok := newRecords[0]["id"].(string); !ok || id == "" || id == recordId {
+				newRecord = newRecords[0]
+			}
+		} else {
+			for _, rec := range newRecords {
+				if id, ok := rec["id"].(string); ok && id == recordId {
+					newRecord = rec
+					break
+				}
+			}
+		}
+		if newRecord == nil {
+			continue
+		}
+		if diff := ComputeRecordDiff(recordId, currentRecord, newRecord); len(diff.Changes) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
+
+// updateLogDiffs returns the diffs of the current records and the update records, if AuditDiff
+func (crud *Crud) updateLogDiffs(newRecords ActionParamsType) []RecordDiffType {
+	if !crud.AuditDiff {
+		return nil
+	}
+	diffs := ComputeRecordDiffs(crud.CurrentRecords, newRecords)
+	if diffs == nil {
+		// no changed fields
+		diffs = []RecordDiffType{}
+	}
+	return diffs
+}
+
+// DecodeAuditDiff function returns the decoded log_diff (json) db-value of the update audit-log
+func DecodeAuditDiff(val interface{}) []RecordDiffType {
+	payload := DecodeAuditPayload(val)
+	if payload == nil {
+		return nil
+	}
+	jsonVal, err := json.Marshal(payload)
+	if err != nil {
+		return nil
+	}
+	var diffs []RecordDiffType
+	if err = json.Unmarshal(jsonVal, &diffs); err != nil {
+		return nil
+	}
+	return diffs
+}
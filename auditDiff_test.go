@@ -0,0 +1,50 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: update audit-log field-level diffs test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestAuditDiff(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the changed fields, and skip the unchanged and stamp fields:",
+		TestFunc: func() {
+			current := map[string]interface{}{"id": "rec-1", "name": "Abi", "age": int64(30), "is_active": true, "updated_by": "user-1"}
+			updated := map[string]interface{}{"name": "Abbey", "age": float64(30), "isActive": false, "updatedBy": "user-2"}
+			diff := ComputeRecordDiff("rec-1", current, updated)
+			mctest.AssertEquals(t, diff.RecordId, "rec-1", "recordId expected")
+			mctest.AssertEquals(t, diff.Changes, []FieldChangeType{
+				{Field: "isActive", OldValue: true, NewValue: false},
+				{Field: "name", OldValue: "Abi", NewValue: "Abbey"},
+			}, "changed fields expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should match the update records by id:",
+		TestFunc: func() {
+			current := []map[string]interface{}{
+				{"id": "rec-1", "name": "Abi"},
+				{"id": "rec-2", "name": "Ola"},
+				{"id": "rec-3", "name": "Ade"},
+			}
+			diffs := ComputeRecordDiffs(current, ActionParamsType{
+				{"id": "rec-2", "name": "Olu"},
+				{"id": "rec-3", "name": "Ade"},
+			})
+			mctest.AssertEquals(t, len(diffs), 1, "one changed record expected")
+			mctest.AssertEquals(t, diffs[0].RecordId, "rec-2", "changed recordId expected")
+			diffs = ComputeRecordDiffs(current, ActionParamsType{{"name": "Ade"}})
+			mctest.AssertEquals(t, len(diffs), 2, "single update record should apply to all records")
+			mctest.AssertEquals(t, DecodeAuditDiff(`[{"recordId":"rec-1","changes":[{"field":"name","oldValue":"Abi","newValue":"Ade"}]}]`), []RecordDiffType{
+				{RecordId: "rec-1", Changes: []FieldChangeType{{Field: "name", OldValue: "Abi", NewValue: "Ade"}}},
+			}, "decoded log_diff expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,261 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: driver-agnostic audit-log engine, of the audit-log executor (db or transaction), with the
+// table-driven log type validation
+
+package mccrud
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"strings"
+	"time"
+)
+
+// AuditExecutor is the audit-log db or transaction: *sql.DB, *sqlx.DB, *sql.Tx or *sqlx.Tx
+type AuditExecutor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// auditTxBeginner is the audit-log db (executor) of the hash-chain transaction
+type auditTxBeginner interface {
+	Begin() (*sql.Tx, error)
+}
+
+// ErrUnknownLogType is the unknown log type validation error
+var ErrUnknownLogType = errors.New("unknown log type and/or incomplete log information")
+
+// auditLogRule is the log type validation rule: the log records (and new log records) required messages
+type auditLogRule struct {
+	recordsMessage    string
+	newRecordsMessage string
+}
+
+// auditLogRules is the validation rules, by log type
+var auditLogRules = map[string]auditLogRule{
+	CreateLog: {recordsMessage: "Created record(s) information is required."},
+	UpdateLog: {
+		recordsMessage:    "Updated record(s) information is required.",
+		newRecordsMessage: "New/Update record(s) information is required.",
+	},
+	GetLog:    {recordsMessage: "Read/Get Params/Keywords information is required."},
+	ReadLog:   {recordsMessage: "Read/Get Params/Keywords information is required."},
+	DeleteLog: {recordsMessage: "Deleted record(s) information is required."},
+	RemoveLog: {recordsMessage: "Deleted record(s) information is required."},
+	LoginLog:  {recordsMessage: "Login record(s) information is required."},
+	LogoutLog: {recordsMessage: "Logout record(s) information is required."},
+	DenyLog:   {recordsMessage: "Access decision information is required."},
+}
+
+// ValidateAuditLog function validates the audit-log params, by the log type rule. The unknown log type returns
+// ErrUnknownLogType.
+func ValidateAuditLog(logType, userId string, options AuditLogOptionsType) error {
+	rule, ok := auditLogRules[strings.ToLower(logType)]
+	if !ok {
+		return ErrUnknownLogType
+	}
+	var errorMessages []string
+	if options.TableName == "" {
+		errorMessages = append(errorMessages, "Table or Collection name is required.")
+	}
+	if userId == "" {
+		errorMessages = append(errorMessages, "userId is required.")
+	}
+	if options.LogRecords == nil {
+		errorMessages = append(errorMessages, rule.recordsMessage)
+	}
+	if rule.newRecordsMessage != "" && options.NewLogRecords == nil {
+		errorMessages = append(errorMessages, rule.newRecordsMessage)
+	}
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, " | "))
+	}
+	return nil
+}
+
+// AuditEngine writes the audit-log entries to the audit table, by the executor (db or transaction)
+type AuditEngine struct {
+	Executor   AuditExecutor
+	AuditTable string
+	HashChain  bool   // hash-chained (tamper-evident) audit-log entries
+	ChainScope string // hash-chain per table (AuditChainTableScope, default) or global (AuditChainGlobalScope)
+	ChainTable string // hash-chain heads (chain_key, chain_seq, hash, archived_seq, archived_hash) table, default: audit_chains
+	LogContext bool   // store the audit context, in the audit-table context columns
+}
+
+// NewAuditEngine constructor returns a new AuditEngine instance, of the executor and audit table
+func NewAuditEngine(executor AuditExecutor, auditTable string) AuditEngine {
+	engine := AuditEngine{
+		Executor:   executor,
+		AuditTable: auditTable,
+	}
+	// default value
+	if engine.AuditTable == "" {
+		engine.AuditTable = "audits"
+	}
+	return engine
+}
+
+// chainTable returns the hash-chain heads table
+func (engine AuditEngine) chainTable() string {
+	if engine.ChainTable == "" {
+		return "audit_chains"
+	}
+	return engine.ChainTable
+}
+
+// AuditLog method validates and writes the audit-log entry
+func (engine AuditEngine) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	logType = strings.ToLower(logType)
+	// validate params
+	if err := ValidateAuditLog(logType, userId, options); err != nil {
+		if err == ErrUnknownLogType {
+			return mcresponse.GetResMessage("logError",
+				mcresponse.ResponseMessageOptions{
+					Message: "Unknown log type and/or incomplete log information",
+					Value:   nil,
+				}), err
+		}
+		return mcresponse.GetResMessage("paramsError",
+			mcresponse.ResponseMessageOptions{
+				Message: err.Error(),
+				Value:   nil,
+			}), err
+	}
+	logAt := options.LogAt
+	if logAt.IsZero() {
+		logAt = time.Now()
+	}
+	if engine.HashChain {
+		// the chained entries log_at, stored and hashed at the db (microseconds) precision
+		logAt = logAt.UTC().Truncate(time.Microsecond)
+	}
+	// json-values
+	logRecs, _ := json.Marshal(options.LogRecords)
+	entry := AuditChainEntryType{
+		TableName:  options.TableName,
+		LogRecords: string(logRecs),
+		LogType:    logType,
+		LogBy:      userId,
+		LogAt:      logAt,
+	}
+	if logType == UpdateLog {
+		newLogRecs, _ := json.Marshal(options.NewLogRecords)
+		entry.NewLogRecords = string(newLogRecs)
+		if options.LogDiff != nil {
+			// field-level changes, by record id
+			logDiff, _ := json.Marshal(options.LogDiff)
+			entry.LogDiff = string(logDiff)
+		}
+	}
+	if engine.LogContext {
+		entry.Context = options.AuditContext
+	}
+	// perform db-log-insert action
+	var (
+		dbResult sql.Result
+		err      error
+	)
+	if engine.HashChain {
+		dbResult, err = engine.ChainLog(entry)
+	} else {
+		dbResult, err = engine.insertLog(engine.Executor, entry, nil, nil)
+	}
+	// Handle error
+	if err != nil {
+		errMsg := fmt.Sprintf("%v", err.Error())
+		return mcresponse.GetResMessage("logError",
+			mcresponse.ResponseMessageOptions{
+				Message: errMsg,
+				Value:   nil,
+			}), errors.New(errMsg)
+	}
+	return mcresponse.GetResMessage("success",
+		mcresponse.ResponseMessageOptions{
+			Message: "successful audit-log action",
+			Value:   dbResult,
+		}), nil
+}
+
+// insertLog inserts the audit-log entry, with the extra (e.g. hash-chain) fields and values
+func (engine AuditEngine) insertLog(executor AuditExecutor, entry AuditChainEntryType, extraFields []string, extraValues []interface{}) (sql.Result, error) {
+	fields := []string{"table_name", "log_records"}
+	values := []interface{}{entry.TableName, entry.LogRecords}
+	if entry.NewLogRecords != nil {
+		fields = append(fields, "new_log_records")
+		values = append(values, entry.NewLogRecords)
+	}
+	fields = append(fields, "log_type", "log_by", "log_at")
+	values = append(values, entry.LogType, entry.LogBy, entry.LogAt)
+	if entry.LogDiff != nil {
+		fields = append(fields, "log_diff")
+		values = append(values, entry.LogDiff)
+	}
+	if engine.LogContext {
+		fields = append(fields, auditContextFields...)
+		values = append(values, entry.Context.values()...)
+	}
+	fields = append(fields, extraFields...)
+	values = append(values, extraValues...)
+	var placeholders []string
+	for i := range values {
+		placeholders = append(placeholders, fmt.Sprintf("$%v", i+1))
+	}
+	// compose SQL-script
+	sqlScript := fmt.Sprintf("INSERT INTO %v(%v) VALUES (%v)", engine.AuditTable, strings.Join(fields, ", "), strings.Join(placeholders, ", "))
+	return executor.Exec(sqlScript, values...)
+}
+
+// ChainLog method inserts the hash-chained audit-log entry, in a transaction of the executor db, or in the executor
+// transaction. The chain-head row (chain table) lock serializes the concurrent writers of the same chain, until the
+// transaction commit.
+func (engine AuditEngine) ChainLog(entry AuditChainEntryType) (sql.Result, error) {
+	beginner, ok := engine.Executor.(auditTxBeginner)
+	if !ok {
+		return engine.chainLog(engine.Executor, entry)
+	}
+	tx, err := beginner.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func(tx *sql.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+	dbResult, err := engine.chainLog(tx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return dbResult, nil
+}
+
+// chainLog inserts the hash-chained audit-log entry, by the transaction executor
+func (engine AuditEngine) chainLog(executor AuditExecutor, entry AuditChainEntryType) (sql.Result, error) {
+	entry.ChainKey = AuditChainKey(engine.ChainScope, entry.TableName)
+	// create the chain head, if not exists
+	headScript := fmt.Sprintf("INSERT INTO %v(chain_key, chain_seq, hash, archived_seq, archived_hash) VALUES ($1, 0, '', 0, '') ON CONFLICT (chain_key) DO NOTHING", engine.chainTable())
+	if _, err := executor.Exec(headScript, entry.ChainKey); err != nil {
+		return nil, err
+	}
+	// next sequence and previous hash
+	seqScript := fmt.Sprintf("UPDATE %v SET chain_seq=chain_seq+1 WHERE chain_key=$1 RETURNING chain_seq, hash", engine.chainTable())
+	if err := executor.QueryRow(seqScript, entry.ChainKey).Scan(&entry.ChainSeq, &entry.PrevHash); err != nil {
+		return nil, err
+	}
+	entry.Hash = ComputeAuditHash(entry)
+	dbResult, err := engine.insertLog(executor, entry, []string{"chain_key", "chain_seq", "prev_hash", "hash"}, []interface{}{entry.ChainKey, entry.ChainSeq, entry.PrevHash, entry.Hash})
+	if err != nil {
+		return nil, err
+	}
+	hashScript := fmt.Sprintf("UPDATE %v SET hash=$1 WHERE chain_key=$2", engine.chainTable())
+	if _, err = executor.Exec(hashScript, entry.Hash, entry.ChainKey); err != nil {
+		return nil, err
+	}
+	return dbResult, nil
+}
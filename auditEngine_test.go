@@ -0,0 +1,75 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit engine (executor and validation) test cases
+
+package mccrud
+
+import (
+	"database/sql"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+// recordExecutor records the executed audit-log scripts and values
+type recordExecutor struct {
+	scripts []string
+	values  [][]interface{}
+}
+
+func (executor *recordExecutor) Exec(query string, args ...interface{}) (sql.Result, error) {
+	executor.scripts = append(executor.scripts, query)
+	executor.values = append(executor.values, args)
+	return nil, nil
+}
+
+func (executor *recordExecutor) QueryRow(query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func TestAuditEngine(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should validate the log type required information:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, ValidateAuditLog("unknown", "user-1", AuditLogOptionsType{TableName: "users"}), ErrUnknownLogType, "unknown log type error expected")
+			err := ValidateAuditLog(UpdateLog, "", AuditLogOptionsType{TableName: "users", LogRecords: "rec"})
+			mctest.AssertEquals(t, err.Error(), "userId is required. | New/Update record(s) information is required.", "update-log required messages expected")
+			mctest.AssertEquals(t, ValidateAuditLog("Create", "user-1", AuditLogOptionsType{TableName: "users", LogRecords: "rec"}), nil, "valid create-log expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should write the audit-log entry by the executor:",
+		TestFunc: func() {
+			executor := &recordExecutor{}
+			engine := NewAuditEngine(executor, "")
+			engine.LogContext = true
+			res, err := engine.AuditLog(UpdateLog, "user-1", AuditLogOptionsType{
+				TableName:     "users",
+				LogRecords:    map[string]interface{}{"id": "rec-1"},
+				NewLogRecords: map[string]interface{}{"id": "rec-1", "name": "Abi"},
+				AuditContext:  AuditContextType{RequestId: "req-1"},
+			})
+			mctest.AssertEquals(t, err, nil, "audit-log error should be: nil")
+			mctest.AssertEquals(t, res.Code, "success", "audit-log response-code should be: success")
+			mctest.AssertEquals(t, executor.scripts, []string{
+				"INSERT INTO audits(table_name, log_records, new_log_records, log_type, log_by, log_at, request_id, correlation_id, client_ip, user_agent, app_id, reason) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+			}, "audit-log script expected")
+			mctest.AssertEquals(t, executor.values[0][6], "req-1", "requestId value expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should not write the invalid audit-log entry:",
+		TestFunc: func() {
+			executor := &recordExecutor{}
+			res, err := NewAuditEngine(executor, "audits").AuditLog("unknown", "user-1", AuditLogOptionsType{TableName: "users"})
+			mctest.AssertEquals(t, err, ErrUnknownLogType, "unknown log type error expected")
+			mctest.AssertEquals(t, res.Code, "logError", "audit-log response-code should be: logError")
+			res, _ = NewAuditEngine(executor, "audits").AuditLog(DeleteLog, "user-1", AuditLogOptionsType{TableName: "users"})
+			mctest.AssertEquals(t, res.Code, "paramsError", "audit-log response-code should be: paramsError")
+			mctest.AssertEquals(t, len(executor.scripts), 0, "no audit-log script expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
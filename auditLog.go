@@ -6,11 +6,8 @@ package mccrud
 
 import (
 	"database/sql"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/abbeymart/mcresponse"
-	"strings"
 	"time"
 )
 
@@ -27,6 +24,9 @@ type AuditLogOptionsType struct {
 	NewLogRecords interface{}
 	QueryParams   QueryParamType
 	RecordIds     []string
+	LogDiff       []RecordDiffType // update-log field-level changes, stored in the log_diff column, if specified
+	LogAt         time.Time        // log time, default: now
+	AuditContext  AuditContextType // request metadata, stored in the context columns, if LogContext
 }
 
 type AuditLogger interface {
@@ -70,6 +70,7 @@ const (
 	RemoveLog = "remove"
 	LoginLog  = "login"
 	LogoutLog = "logout"
+	DenyLog   = "deny"
 )
 
 func NewAuditLog(auditDb *sql.DB, auditTable string) LogParam {
@@ -92,264 +93,43 @@ func (log LogParam) String() string {
 		log.AuditTable)
 }
 
+// AuditLog method validates and writes the audit-log entry, by the audit engine
 func (log LogParam) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
-	// variables
-	logType = strings.ToLower(logType)
-	logBy := userId
-
-	var (
-		tableName     = options.TableName
-		sqlScript     = ""
-		logRecords    interface{}
-		newLogRecords interface{}
-		logAt         = time.Now()
-		dbResult      sql.Result
-		err           error
-	)
-
-	// json-values
-	logRecs, _ := json.Marshal(options.LogRecords)
-	newLogRecs, _ := json.Marshal(options.NewLogRecords)
-	logRecords = string(logRecs)
-	newLogRecords = string(newLogRecs)
-	// log-cases
-	switch logType {
-	case CreateLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Created record(s) information is required."
-			} else {
-				errorMessage = "Created record(s) information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		fmt.Printf("query: %v \n", sqlScript)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	case UpdateLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Updated record(s) information is required."
-			} else {
-				errorMessage = "Updated record(s) information is required."
-			}
-		}
-		if newLogRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | New/Update record(s) information is required."
-			} else {
-				errorMessage = "New/Update record(s) information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, new_log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5, $6)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, newLogRecords, logType, logBy, logAt)
-	case GetLog, ReadLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Read/Get Params/Keywords information is required."
-			} else {
-				errorMessage = "Read/Get Params/Keywords information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	case DeleteLog, RemoveLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Deleted record(s) information is required."
-			} else {
-				errorMessage = "Deleted record(s) information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	case LoginLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Login record(s) information is required."
-			} else {
-				errorMessage = "Login record(s) information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	case LogoutLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Logout record(s) information is required."
-			} else {
-				errorMessage = "Logout record(s) information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	default:
-		return mcresponse.GetResMessage("logError",
-			mcresponse.ResponseMessageOptions{
-				Message: "Unknown log type and/or incomplete log information",
-				Value:   nil,
-			}), errors.New("unknown log type and/or incomplete log information")
-	}
+	return log.engine().AuditLog(logType, userId, options)
+}
 
-	// Handle error
-	if err != nil {
-		errMsg := fmt.Sprintf("%v", err.Error())
-		return mcresponse.GetResMessage("logError",
-			mcresponse.ResponseMessageOptions{
-				Message: errMsg,
-				Value:   nil,
-			}), errors.New(errMsg)
-	}
-	return mcresponse.GetResMessage("success",
-		mcresponse.ResponseMessageOptions{
-			Message: "successful audit-log action",
-			Value:   dbResult,
-		}), nil
+// engine returns the audit engine of the audit db
+func (log LogParam) engine() AuditEngine {
+	return NewAuditEngine(log.AuditDb, log.AuditTable)
 }
 
 func (log LogParam) CreateLog(table string, logRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(CreateLog, userId, AuditLogOptionsType{
+		TableName:  table,
+		LogRecords: logRecords,
+	})
 }
 
 func (log LogParam) UpdateLog(tableName string, logRecords interface{}, newLogRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(UpdateLog, userId, AuditLogOptionsType{
+		TableName:     tableName,
+		LogRecords:    logRecords,
+		NewLogRecords: newLogRecords,
+	})
 }
 
 func (log LogParam) ReadLog(tableName string, logRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(ReadLog, userId, AuditLogOptionsType{
+		TableName:  tableName,
+		LogRecords: logRecords,
+	})
 }
 
 func (log LogParam) DeleteLog(tableName string, logRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(DeleteLog, userId, AuditLogOptionsType{
+		TableName:  tableName,
+		LogRecords: logRecords,
+	})
 }
 
 func (log LogParam) LoginLog(logRecords interface{}, userId string, tableName string) (mcresponse.ResponseMessage, error) {
@@ -358,7 +138,10 @@ func (log LogParam) LoginLog(logRecords interface{}, userId string, tableName st
 		tableName = "users"
 	}
 
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(LoginLog, userId, AuditLogOptionsType{
+		TableName:  tableName,
+		LogRecords: logRecords,
+	})
 }
 
 func (log LogParam) LogoutLog(logRecords interface{}, userId string, tableName string) (mcresponse.ResponseMessage, error) {
@@ -367,5 +150,8 @@ func (log LogParam) LogoutLog(logRecords interface{}, userId string, tableName s
 		tableName = "users"
 	}
 
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(LogoutLog, userId, AuditLogOptionsType{
+		TableName:  tableName,
+		LogRecords: logRecords,
+	})
 }
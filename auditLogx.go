@@ -5,20 +5,22 @@
 package mccrud
 
 import (
-	"database/sql"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/abbeymart/mcresponse"
 	"github.com/jmoiron/sqlx"
-	"strings"
-	"time"
 )
 
 // LogParamX interfaces / types
 type LogParamX struct {
 	AuditDb    *sqlx.DB
 	AuditTable string
+	HashChain  bool              // hash-chained (tamper-evident) audit-log entries
+	ChainScope string            // hash-chain per table (AuditChainTableScope, default) or global (AuditChainGlobalScope)
+	ChainTable string            // hash-chain heads (chain_key, chain_seq, hash, archived_seq, archived_hash) table, default: audit_chains
+	AuditDiff  bool              // the audit-table log_diff column is specified
+	Writer     *AsyncAuditWriter // asynchronous (queued) audit-log writer, if specified
+	LogContext bool              // store the audit context, in the audit-table context columns
+	execer     AuditExecutor     // audit-log transaction (batch), default: AuditDb
 }
 
 type AuditLogOptionsXType struct {
@@ -81,261 +83,68 @@ func (log LogParamX) String() string {
 		log.AuditTable)
 }
 
+// AuditLog method validates and writes the audit-log entry, by the audit engine, or queues the entry, by the
+// asynchronous writer, if specified
 func (log LogParamX) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
-	// variables
-	logType = strings.ToLower(logType)
-	logBy := userId
-
-	var (
-		tableName     = options.TableName
-		sqlScript     = ""
-		logRecords    interface{}
-		newLogRecords interface{}
-		logAt         = time.Now()
-		dbResult      sql.Result
-		err           error
-	)
-	// log-cases
-	logRecs, _ := json.Marshal(options.LogRecords)
-	newLogRecs, _ := json.Marshal(options.NewLogRecords)
-	logRecords = string(logRecs)
-	newLogRecords = string(newLogRecs)
-	switch logType {
-	case CreateLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Created record(s) information is required."
-			} else {
-				errorMessage = "Created record(s) information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	case UpdateLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Updated record(s) information is required."
-			} else {
-				errorMessage = "Updated record(s) information is required."
-			}
-		}
-		if newLogRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | New/Update record(s) information is required."
-			} else {
-				errorMessage = "New/Update record(s) information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, new_log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5, $6)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, newLogRecords, logType, logBy, logAt)
-	case GetLog, ReadLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Read/Get Params/Keywords information is required."
-			} else {
-				errorMessage = "Read/Get Params/Keywords information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	case DeleteLog, RemoveLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Deleted record(s) information is required."
-			} else {
-				errorMessage = "Deleted record(s) information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
+	// asynchronous audit-log: the write failures are reported by the writer OnError callback
+	if log.Writer != nil {
+		if err := log.Writer.Write(logType, userId, options); err != nil {
+			return mcresponse.GetResMessage("logError",
 				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
+					Message: err.Error(),
 					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	case LoginLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Login record(s) information is required."
-			} else {
-				errorMessage = "Login record(s) information is required."
-			}
-		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	case LogoutLog:
-		// validate params
-		var errorMessage = ""
-		if tableName == "" {
-			errorMessage = "Table or Collection name is required."
-		}
-		if logBy == "" {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | userId is required."
-			} else {
-				errorMessage = "userId is required."
-			}
-		}
-		if logRecords == nil {
-			if errorMessage != "" {
-				errorMessage = errorMessage + " | Logout record(s) information is required."
-			} else {
-				errorMessage = "Logout record(s) information is required."
-			}
+				}), err
 		}
-		if errorMessage != "" {
-			return mcresponse.GetResMessage("paramsError",
-				mcresponse.ResponseMessageOptions{
-					Message: errorMessage,
-					Value:   nil,
-				}), errors.New(errorMessage)
-		}
-		// compose SQL-script
-		sqlScript = fmt.Sprintf("INSERT INTO %v(table_name, log_records, log_type, log_by, log_at ) VALUES ($1, $2, $3, $4, $5)", log.AuditTable)
-		// perform db-log-insert action
-		dbResult, err = log.AuditDb.Exec(sqlScript, tableName, logRecords, logType, logBy, logAt)
-	default:
-		return mcresponse.GetResMessage("logError",
+		return mcresponse.GetResMessage("success",
 			mcresponse.ResponseMessageOptions{
-				Message: "Unknown log type and/or incomplete log information",
+				Message: "queued audit-log action",
 				Value:   nil,
-			}), errors.New("unknown log type and/or incomplete log information")
+			}), nil
 	}
+	return log.engine().AuditLog(logType, userId, options)
+}
 
-	// Handle error
-	if err != nil {
-		errMsg := fmt.Sprintf("%v", err.Error())
-		return mcresponse.GetResMessage("logError",
-			mcresponse.ResponseMessageOptions{
-				Message: errMsg,
-				Value:   nil,
-			}), errors.New(errMsg)
+// engine returns the audit engine of the audit db, or of the batch transaction, if specified
+func (log LogParamX) engine() AuditEngine {
+	var executor AuditExecutor = log.AuditDb
+	if log.execer != nil {
+		executor = log.execer
 	}
-	return mcresponse.GetResMessage("success",
-		mcresponse.ResponseMessageOptions{
-			Message: "successful audit-log action",
-			Value:   dbResult,
-		}), nil
+	engine := NewAuditEngine(executor, log.AuditTable)
+	engine.HashChain = log.HashChain
+	engine.ChainScope = log.ChainScope
+	engine.ChainTable = log.ChainTable
+	engine.LogContext = log.LogContext
+	return engine
 }
 
 func (log LogParamX) CreateLogx(table string, logRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(CreateLog, userId, AuditLogOptionsType{
+		TableName:  table,
+		LogRecords: logRecords,
+	})
 }
 
 func (log LogParamX) UpdateLogx(tableName string, logRecords interface{}, newLogRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(UpdateLog, userId, AuditLogOptionsType{
+		TableName:     tableName,
+		LogRecords:    logRecords,
+		NewLogRecords: newLogRecords,
+	})
 }
 
 func (log LogParamX) ReadLogx(tableName string, logRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(ReadLog, userId, AuditLogOptionsType{
+		TableName:  tableName,
+		LogRecords: logRecords,
+	})
 }
 
 func (log LogParamX) DeleteLogx(tableName string, logRecords interface{}, userId string) (mcresponse.ResponseMessage, error) {
-
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(DeleteLog, userId, AuditLogOptionsType{
+		TableName:  tableName,
+		LogRecords: logRecords,
+	})
 }
 
 func (log LogParamX) LoginLogx(logRecords interface{}, userId string, tableName string) (mcresponse.ResponseMessage, error) {
@@ -344,7 +153,10 @@ func (log LogParamX) LoginLogx(logRecords interface{}, userId string, tableName
 		tableName = "users"
 	}
 
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(LoginLog, userId, AuditLogOptionsType{
+		TableName:  tableName,
+		LogRecords: logRecords,
+	})
 }
 
 func (log LogParamX) LogoutLogx(logRecords interface{}, userId string, tableName string) (mcresponse.ResponseMessage, error) {
@@ -353,5 +165,8 @@ func (log LogParamX) LogoutLogx(logRecords interface{}, userId string, tableName
 		tableName = "users"
 	}
 
-	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{}), nil
+	return log.AuditLog(LogoutLog, userId, AuditLogOptionsType{
+		TableName:  tableName,
+		LogRecords: logRecords,
+	})
 }
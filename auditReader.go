@@ -0,0 +1,409 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log query (filters and pagination) and reporting (summaries) API
+
+package mccrud
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+// DefaultAuditLimit is the default page size of the audit-log query
+const DefaultAuditLimit = 100
+
+// AuditQueryType is the audit-log query filters and pagination. Empty filters are ignored.
+type AuditQueryType struct {
+	TableName string    `json:"tableName"`
+	RecordId  string    `json:"recordId"` // record id, in the log_records or new_log_records
+	LogBy     string    `json:"logBy"`
+	LogTypes  []string  `json:"logTypes"`
+	From      time.Time `json:"from"` // log_at >= from
+	To        time.Time `json:"to"`   // log_at < to
+	Skip      int       `json:"skip"`
+	Limit     int       `json:"limit"` // default: DefaultAuditLimit
+	Ascending bool      `json:"ascending"`
+	// audit context filters, if LogContext
+	RequestId     string `json:"requestId"`
+	CorrelationId string `json:"correlationId"`
+	ClientIp      string `json:"clientIp"`
+	AppId         string `json:"appId"`
+}
+
+// AuditRecordType is the audit-log record, with the decoded (json) log_records and new_log_records payloads
+type AuditRecordType struct {
+	Id            string           `json:"id"`
+	TableName     string           `json:"tableName"`
+	LogRecords    interface{}      `json:"logRecords"`
+	NewLogRecords interface{}      `json:"newLogRecords"`
+	LogType       string           `json:"logType"`
+	LogBy         string           `json:"logBy"`
+	LogAt         time.Time        `json:"logAt"`
+	LogDiff       []RecordDiffType `json:"logDiff"`      // update-log field-level changes, if AuditDiff
+	AuditContext  AuditContextType `json:"auditContext"` // request metadata, if LogContext
+}
+
+// AuditResultType is the GetAudits response value
+type AuditResultType struct {
+	Records    []AuditRecordType `json:"records"`
+	TotalCount int               `json:"totalCount"`
+	Skip       int               `json:"skip"`
+	Limit      int               `json:"limit"`
+}
+
+// AuditSummaryType is the count of the audit-log operations, per user, day, table and log type
+type AuditSummaryType struct {
+	LogBy     string `json:"logBy"`
+	LogDate   string `json:"logDate"` // yyyy-mm-dd
+	TableName string `json:"tableName"`
+	LogType   string `json:"logType"`
+	Count     int    `json:"count"`
+}
+
+// AuditReader reads the audit-log records, of the audit table
+type AuditReader struct {
+	AuditDb    *sqlx.DB
+	AuditTable string
+	AuditDiff  bool                              // read the log_diff column
+	LogContext bool                              // read the audit context columns
+	AppId      string                            // scopes the audit-log queries to the app/tenant (app_id), if specified
+	Access     func() mcresponse.ResponseMessage // read access check of the audit-log queries, if specified
+}
+
+// NewAuditReader constructor returns a new AuditReader instance
+func NewAuditReader(auditDb *sqlx.DB, auditTable string) *AuditReader {
+	reader := &AuditReader{
+		AuditDb:    auditDb,
+		AuditTable: auditTable,
+	}
+	if reader.AuditTable == "" {
+		reader.AuditTable = "audits"
+	}
+	return reader
+}
+
+// auditReader returns the AuditReader of the crud audit-db and audit-table, scoped to the app/tenant (app_id
+// context column), in multi-tenant mode with AuditLogContext, without the read access check, e.g. of the
+// access-checked crud tasks (Revert)
+func (crud *Crud) auditReader() *AuditReader {
+	reader := NewAuditReader(crud.AuditDb, crud.AuditTable)
+	reader.AuditDiff = crud.AuditDiff
+	reader.LogContext = crud.AuditLogContext
+	if crud.AuditLogContext {
+		reader.AppId = crud.TenantId()
+	}
+	return reader
+}
+
+// AuditReader method returns the AuditReader of the crud audit-db and audit-table, scoped to the app/tenant, in
+// multi-tenant mode. The audit-log queries (all the tables payloads) require the app access and AuditLogContext
+// (app_id scope), in multi-tenant mode, and the admin access, if CheckAccess.
+func (crud *Crud) AuditReader() *AuditReader {
+	reader := crud.auditReader()
+	reader.Access = func() mcresponse.ResponseMessage {
+		if crud.TenantMode {
+			if !crud.AuditLogContext {
+				return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+					Message: "Unauthorized: the audit-log context (app_id) is required, to read the audit-log in multi-tenant mode",
+					Value:   nil,
+				})
+			}
+			if appRes := crud.CheckAppAccess(); appRes.Code != "success" {
+				return appRes
+			}
+		}
+		if crud.CheckAccess {
+			return crud.CheckAdminAccess()
+		}
+		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+			Message: "Action authorised / permitted.",
+			Value:   nil,
+		})
+	}
+	return reader
+}
+
+// checkAccess performs the read access check (Access) of the audit-log queries, if specified
+func (reader *AuditReader) checkAccess() mcresponse.ResponseMessage {
+	if reader.Access == nil {
+		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+			Message: "Action authorised / permitted.",
+			Value:   nil,
+		})
+	}
+	return reader.Access()
+}
+
+// DecodeAuditPayload function returns the decoded json-payload (log_records, new_log_records) of the audit-log
+// db-value: json-bytes/string or base64-encoded json-string
+func DecodeAuditPayload(val interface{}) interface{} {
+	var jsonBytes []byte
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case []byte:
+		jsonBytes = v
+	case string:
+		jsonBytes = []byte(v)
+	default:
+		return val
+	}
+	var payload interface{}
+	if err := json.Unmarshal(jsonBytes, &payload); err == nil {
+		return payload
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(string(jsonBytes)); err == nil {
+		if err = json.Unmarshal(decoded, &payload); err == nil {
+			return payload
+		}
+	}
+	return string(jsonBytes)
+}
+
+// likeValue escapes the LIKE pattern special characters (%, _ and \) of the value
+func likeValue(val string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(val)
+}
+
+// ComputeAuditWhere function computes the where-script and values of the audit-log query filters
+func ComputeAuditWhere(query AuditQueryType) (string, []interface{}) {
+	var conditions []string
+	var values []interface{}
+	addCondition := func(condition string, value interface{}) {
+		values = append(values, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(values)))
+	}
+	if query.TableName != "" {
+		addCondition("table_name=$%v", query.TableName)
+	}
+	if query.RecordId != "" {
+		pattern := fmt.Sprintf(`%%"%v"%%`, likeValue(query.RecordId))
+		values = append(values, pattern)
+		conditions = append(conditions, fmt.Sprintf("(CAST(log_records AS TEXT) LIKE $%v OR CAST(new_log_records AS TEXT) LIKE $%v)", len(values), len(values)))
+	}
+	if query.LogBy != "" {
+		addCondition("log_by=$%v", query.LogBy)
+	}
+	if query.RequestId != "" {
+		addCondition("request_id=$%v", query.RequestId)
+	}
+	if query.CorrelationId != "" {
+		addCondition("correlation_id=$%v", query.CorrelationId)
+	}
+	if query.ClientIp != "" {
+		addCondition("client_ip=$%v", query.ClientIp)
+	}
+	if query.AppId != "" {
+		addCondition("app_id=$%v", query.AppId)
+	}
+	if len(query.LogTypes) > 0 {
+		var logTypes []string
+		for _, logType := range query.LogTypes {
+			logTypes = append(logTypes, strings.ToLower(logType))
+		}
+		conditions = append(conditions, fmt.Sprintf("log_type IN (%v)", ArrayToSQLStringValues(logTypes)))
+	}
+	if !query.From.IsZero() {
+		addCondition("log_at>=$%v", query.From)
+	}
+	if !query.To.IsZero() {
+		addCondition("log_at<$%v", query.To)
+	}
+	if len(conditions) < 1 {
+		return "", values
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), values
+}
+
+// GetAudits method returns the audit-log records of the query filters, by page (skip/limit)
+func (reader *AuditReader) GetAudits(query AuditQueryType) mcresponse.ResponseMessage {
+	if accessRes := reader.checkAccess(); accessRes.Code != "success" {
+		return accessRes
+	}
+	if reader.AppId != "" {
+		query.AppId = reader.AppId
+	}
+	if query.Skip < 0 {
+		query.Skip = 0
+	}
+	if query.Limit <= 0 {
+		query.Limit = DefaultAuditLimit
+	}
+	whereScript, values := ComputeAuditWhere(query)
+	var totalCount int
+	countScript := fmt.Sprintf("SELECT COUNT(*) FROM %v%v", reader.AuditTable, whereScript)
+	if err := reader.AuditDb.QueryRow(countScript, values...).Scan(&totalCount); err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading audit-log records: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	sortOrder := "DESC"
+	if query.Ascending {
+		sortOrder = "ASC"
+	}
+	auditFields := "id, table_name, log_records, new_log_records, log_type, log_by, log_at"
+	if reader.AuditDiff {
+		auditFields += ", log_diff"
+	}
+	if reader.LogContext {
+		auditFields += ", " + strings.Join(auditContextFields, ", ")
+	}
+	auditScript := fmt.Sprintf("SELECT %v FROM %v%v ORDER BY log_at %v, id %v LIMIT %v OFFSET %v", auditFields, reader.AuditTable, whereScript, sortOrder, sortOrder, query.Limit, query.Skip)
+	rows, err := reader.AuditDb.Queryx(auditScript, values...)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading audit-log records: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+	result := AuditResultType{
+		TotalCount: totalCount,
+		Skip:       query.Skip,
+		Limit:      query.Limit,
+	}
+	for rows.Next() {
+		var (
+			record                             AuditRecordType
+			logRecords, newLogRecords, logDiff interface{}
+		)
+		scanValues := []interface{}{&record.Id, &record.TableName, &logRecords, &newLogRecords, &record.LogType, &record.LogBy, &record.LogAt}
+		if reader.AuditDiff {
+			scanValues = append(scanValues, &logDiff)
+		}
+		if reader.LogContext {
+			scanValues = append(scanValues, record.AuditContext.scanValues()...)
+		}
+		if err := rows.Scan(scanValues...); err != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading audit-log records: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		record.LogRecords = DecodeAuditPayload(logRecords)
+		record.NewLogRecords = DecodeAuditPayload(newLogRecords)
+		record.LogDiff = DecodeAuditDiff(logDiff)
+		result.Records = append(result.Records, record)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v of %v audit-log record(s) returned", len(result.Records), totalCount),
+		Value:   result,
+	})
+}
+
+// auditLogDate returns the yyyy-mm-dd value of the db-date value
+func auditLogDate(val interface{}) string {
+	switch v := val.(type) {
+	case time.Time:
+		return v.Format("2006-01-02")
+	case []byte:
+		return auditLogDate(string(v))
+	case string:
+		if len(v) > 10 {
+			return v[:10]
+		}
+		return v
+	}
+	return fmt.Sprintf("%v", val)
+}
+
+// GetAuditSummary method returns the count of the audit-log operations of the query filters, per user, day,
+// table and log type
+func (reader *AuditReader) GetAuditSummary(query AuditQueryType) mcresponse.ResponseMessage {
+	if accessRes := reader.checkAccess(); accessRes.Code != "success" {
+		return accessRes
+	}
+	if reader.AppId != "" {
+		query.AppId = reader.AppId
+	}
+	whereScript, values := ComputeAuditWhere(query)
+	summaryScript := fmt.Sprintf("SELECT log_by, DATE(log_at) AS log_date, table_name, log_type, COUNT(*) FROM %v%v GROUP BY log_by, DATE(log_at), table_name, log_type ORDER BY log_date, log_by, table_name, log_type", reader.AuditTable, whereScript)
+	rows, err := reader.AuditDb.Queryx(summaryScript, values...)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading audit-log summary: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+	var summaries []AuditSummaryType
+	for rows.Next() {
+		var (
+			summary AuditSummaryType
+			logDate interface{}
+		)
+		if err := rows.Scan(&summary.LogBy, &logDate, &summary.TableName, &summary.LogType, &summary.Count); err != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading audit-log summary: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		summary.LogDate = auditLogDate(logDate)
+		summaries = append(summaries, summary)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v audit-log summary record(s) returned", len(summaries)),
+		Value:   summaries,
+	})
+}
+
+// GetAudit method returns the audit-log record of the audit id
+func (reader *AuditReader) GetAudit(auditId string) mcresponse.ResponseMessage {
+	if accessRes := reader.checkAccess(); accessRes.Code != "success" {
+		return accessRes
+	}
+	auditFields := "id, table_name, log_records, new_log_records, log_type, log_by, log_at"
+	if reader.AuditDiff {
+		auditFields += ", log_diff"
+	}
+	if reader.LogContext {
+		auditFields += ", " + strings.Join(auditContextFields, ", ")
+	}
+	var (
+		record                             AuditRecordType
+		logRecords, newLogRecords, logDiff interface{}
+	)
+	scanValues := []interface{}{&record.Id, &record.TableName, &logRecords, &newLogRecords, &record.LogType, &record.LogBy, &record.LogAt}
+	if reader.AuditDiff {
+		scanValues = append(scanValues, &logDiff)
+	}
+	if reader.LogContext {
+		scanValues = append(scanValues, record.AuditContext.scanValues()...)
+	}
+	auditScript := fmt.Sprintf("SELECT %v FROM %v WHERE id=$1", auditFields, reader.AuditTable)
+	auditValues := []interface{}{auditId}
+	if reader.AppId != "" {
+		auditScript += " AND app_id=$2"
+		auditValues = append(auditValues, reader.AppId)
+	}
+	if err := reader.AuditDb.QueryRowx(auditScript, auditValues...).Scan(scanValues...); err != nil {
+		if err == sql.ErrNoRows {
+			return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Audit-log record %v not found", auditId),
+				Value:   nil,
+			})
+		}
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading audit-log record: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	record.LogRecords = DecodeAuditPayload(logRecords)
+	record.NewLogRecords = DecodeAuditPayload(newLogRecords)
+	record.LogDiff = DecodeAuditDiff(logDiff)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Audit-log record returned",
+		Value:   record,
+	})
+}
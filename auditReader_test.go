@@ -0,0 +1,98 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log query and reporting test cases
+
+package mccrud
+
+import (
+	"encoding/base64"
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestAuditReader(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the audit-log query filters:",
+		TestFunc: func() {
+			from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+			whereScript, values := ComputeAuditWhere(AuditQueryType{
+				TableName: "groups",
+				RecordId:  "rec_1",
+				LogBy:     "user-1",
+				LogTypes:  []string{"Update", "delete"},
+				From:      from,
+			})
+			mctest.AssertEquals(t, whereScript, " WHERE table_name=$1 AND (CAST(log_records AS TEXT) LIKE $2 OR CAST(new_log_records AS TEXT) LIKE $2) AND log_by=$3 AND log_type IN ('update', 'delete') AND log_at>=$4", "where-script expected")
+			mctest.AssertEquals(t, values, []interface{}{"groups", `%"rec\_1"%`, "user-1", from}, "where-values expected")
+			whereScript, values = ComputeAuditWhere(AuditQueryType{})
+			mctest.AssertEquals(t, whereScript, "", "no where-script expected")
+			mctest.AssertEquals(t, len(values), 0, "no where-values expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should decode the json and base64 audit-log payloads:",
+		TestFunc: func() {
+			jsonPayload := `{"logRecords":[{"id":"rec-1","name":"Abi"}]}`
+			expected := map[string]interface{}{"logRecords": []interface{}{map[string]interface{}{"id": "rec-1", "name": "Abi"}}}
+			mctest.AssertEquals(t, DecodeAuditPayload([]byte(jsonPayload)), expected, "json-bytes payload should be decoded")
+			mctest.AssertEquals(t, DecodeAuditPayload(jsonPayload), expected, "json-string payload should be decoded")
+			mctest.AssertEquals(t, DecodeAuditPayload(base64.StdEncoding.EncodeToString([]byte(jsonPayload))), expected, "base64 payload should be decoded")
+			mctest.AssertEquals(t, DecodeAuditPayload(nil), nil, "nil payload expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the audit context filters:",
+		TestFunc: func() {
+			whereScript, values := ComputeAuditWhere(AuditQueryType{RequestId: "req-1", CorrelationId: "cor-1"})
+			mctest.AssertEquals(t, whereScript, " WHERE request_id=$1 AND correlation_id=$2", "context where-script expected")
+			mctest.AssertEquals(t, values, []interface{}{"req-1", "cor-1"}, "context where-values expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should set the crud audit context, as of the log time:",
+		TestFunc: func() {
+			sink := NewMemoryAuditSink()
+			crud := NewCrud(CrudParamsType{TableName: "groups", AppParams: AppParamsType{AppId: "app-1"}}, CrudOptionsType{AuditLogger: sink, TenantMode: true})
+			_, _ = crud.TransLog.AuditLog(CreateLog, "user-1", AuditLogOptionsType{TableName: "groups", LogRecords: map[string]interface{}{"id": "g-1"}})
+			crud.AuditContext = AuditContextType{RequestId: "req-2", Reason: "import"}
+			_, _ = crud.TransLog.AuditLog(CreateLog, "user-1", AuditLogOptionsType{TableName: "groups", LogRecords: map[string]interface{}{"id": "g-1"}})
+			_, _ = crud.TransLog.AuditLog(CreateLog, "user-1", AuditLogOptionsType{TableName: "groups", LogRecords: map[string]interface{}{"id": "g-1"}, AuditContext: AuditContextType{RequestId: "req-3"}})
+			entries := sink.Entries()
+			mctest.AssertEquals(t, len(entries), 3, "three audit-log entries expected")
+			mctest.AssertEquals(t, entries[0].AuditContext, AuditContextType{AppId: "app-1"}, "tenant app-id audit context expected")
+			mctest.AssertEquals(t, entries[1].AuditContext, AuditContextType{RequestId: "req-2", AppId: "app-1", Reason: "import"}, "changed crud audit context expected")
+			mctest.AssertEquals(t, entries[2].AuditContext, AuditContextType{RequestId: "req-3"}, "entry audit context expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should require the admin access and the app/tenant scope, of the crud audit reader:",
+		TestFunc: func() {
+			secret := []byte("test-secret")
+			token, _ := SignJwtToken(map[string]interface{}{"sub": "user-1", "exp": time.Now().Add(time.Hour).Unix()}, secret, "HS256")
+			crud := &Crud{
+				CrudParamsType:  CrudParamsType{TableName: "groups", UserInfo: UserInfoType{UserId: "user-1", Token: token}},
+				CrudOptionsType: CrudOptionsType{CheckAccess: true, Authenticator: NewJwtAuthenticator(secret, JwtAuthenticator{})},
+			}
+			reader := crud.AuditReader()
+			mctest.AssertEquals(t, reader.GetAudits(AuditQueryType{TableName: "users"}).Code, "unAuthorized", "non-admin audit-log query denied")
+			mctest.AssertEquals(t, reader.GetAuditSummary(AuditQueryType{}).Code, "unAuthorized", "non-admin audit-log summary denied")
+			mctest.AssertEquals(t, reader.GetAudit("audit-1").Code, "unAuthorized", "non-admin audit-log record denied")
+			mctest.AssertEquals(t, reader.GetHistory("groups", "g-1").Code, "unAuthorized", "non-admin record history denied")
+			mctest.AssertEquals(t, crud.auditReader().Access == nil, true, "no read access check of the crud tasks reader expected")
+			tenantCrud := &Crud{
+				CrudParamsType:  CrudParamsType{TableName: "groups", AppParams: AppParamsType{AppId: "app-1"}},
+				CrudOptionsType: CrudOptionsType{TenantMode: true},
+			}
+			mctest.AssertEquals(t, tenantCrud.AuditReader().GetAudits(AuditQueryType{}).Code, "unAuthorized", "unscoped (without the audit context) tenant audit-log query denied")
+			tenantCrud.AuditLogContext = true
+			mctest.AssertEquals(t, tenantCrud.AuditReader().AppId, "app-1", "tenant-scoped audit reader expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,288 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log payload redaction (drop, mask, hash or truncate) rules, by table and field
+
+package mccrud
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/abbeymart/mcresponse"
+	"github.com/asaskevich/govalidator"
+	"strings"
+)
+
+// redaction actions
+const (
+	RedactDrop     = "drop"     // remove the field
+	RedactMask     = "mask"     // replace the value characters with *, keeping the email domain or the KeepChars last characters
+	RedactHash     = "hash"     // replace the value with its sha256 (or HMAC-sha256, of the redactor HashKey) hex digest
+	RedactTruncate = "truncate" // keep the KeepChars (default: 4) first characters
+	RedactKeep     = "keep"     // log the value, e.g. to exempt a table field from the default rules
+)
+
+// RedactRuleType is the redaction action of the audit-log payload field (camelCase or underscore name) of the
+// table. The empty TableName matches all the tables.
+type RedactRuleType struct {
+	TableName string `json:"tableName"`
+	Field     string `json:"field"`
+	Action    string `json:"action"`
+	KeepChars int    `json:"keepChars"`
+}
+
+// DefaultRedactRules is the redaction rules of the credentials and contact fields (e.g. UserInfoType token and
+// email), applied with the crud AuditRedactRules
+var DefaultRedactRules = []RedactRuleType{
+	{Field: "password", Action: RedactDrop},
+	{Field: "confirm_password", Action: RedactDrop},
+	{Field: "token", Action: RedactDrop},
+	{Field: "access_token", Action: RedactDrop},
+	{Field: "refresh_token", Action: RedactDrop},
+	{Field: "secret", Action: RedactDrop},
+	{Field: "api_key", Action: RedactDrop},
+	{Field: "email", Action: RedactMask},
+}
+
+// redactFieldName returns the comparable (underscore, lower case) field name
+func redactFieldName(field string) string {
+	return strings.ToLower(govalidator.CamelCaseToUnderscore(field))
+}
+
+// AuditRedactor applies the redaction rules to the audit-log payloads. The table rules override the all-tables
+// rules, and the later rules override the prior rules, of the same field.
+type AuditRedactor struct {
+	Rules   []RedactRuleType
+	HashKey []byte // HMAC key of the hash action, if specified
+}
+
+// NewAuditRedactor constructor returns a new AuditRedactor instance, of the default and the specified rules
+func NewAuditRedactor(rules []RedactRuleType) *AuditRedactor {
+	redactor := &AuditRedactor{}
+	redactor.Rules = append(redactor.Rules, DefaultRedactRules...)
+	redactor.Rules = append(redactor.Rules, rules...)
+	return redactor
+}
+
+// tableRules returns the field rules (by comparable field name) of the table
+func (redactor *AuditRedactor) tableRules(tableName string) map[string]RedactRuleType {
+	rules := map[string]RedactRuleType{}
+	for _, rule := range redactor.Rules {
+		if rule.TableName == "" {
+			rules[redactFieldName(rule.Field)] = rule
+		}
+	}
+	for _, rule := range redactor.Rules {
+		if rule.TableName != "" && rule.TableName == tableName {
+			rules[redactFieldName(rule.Field)] = rule
+		}
+	}
+	for field, rule := range rules {
+		if rule.Action == RedactKeep {
+			delete(rules, field)
+		}
+	}
+	return rules
+}
+
+// RedactedFields method returns the redacted (comparable) field names of the table
+func (redactor *AuditRedactor) RedactedFields(tableName string) []string {
+	var fields []string
+	for field := range redactor.tableRules(tableName) {
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// redactValue returns the redacted value of the rule action
+func (redactor *AuditRedactor) redactValue(rule RedactRuleType, val interface{}) interface{} {
+	if val == nil {
+		return nil
+	}
+	strVal, ok := val.(string)
+	if !ok {
+		jsonVal, _ := json.Marshal(val)
+		strVal = string(jsonVal)
+	}
+	switch rule.Action {
+	case RedactHash:
+		if len(redactor.HashKey) > 0 {
+			mac := hmac.New(sha256.New, redactor.HashKey)
+			mac.Write([]byte(strVal))
+			return "hmac-sha256:" + hex.EncodeToString(mac.Sum(nil))
+		}
+		hash := sha256.Sum256([]byte(strVal))
+		return "sha256:" + hex.EncodeToString(hash[:])
+	case RedactTruncate:
+		keepChars := rule.KeepChars
+		if keepChars <= 0 {
+			keepChars = 4
+		}
+		chars := []rune(strVal)
+		if len(chars) <= keepChars {
+			return strVal
+		}
+		return string(chars[:keepChars]) + "..."
+	default:
+		return maskValue(strVal, rule.KeepChars)
+	}
+}
+
+// maskValue returns the masked value: the email local part (except the first character), or the characters except
+// the keepChars last characters, are replaced with *
+func maskValue(val string, keepChars int) string {
+	if atIndex := strings.LastIndex(val, "@"); atIndex > 0 {
+		local := []rune(val[:atIndex])
+		return string(local[:1]) + strings.Repeat("*", len(local)-1) + val[atIndex:]
+	}
+	chars := []rune(val)
+	if keepChars < 0 || keepChars >= len(chars) {
+		keepChars = 0
+	}
+	return strings.Repeat("*", len(chars)-keepChars) + string(chars[len(chars)-keepChars:])
+}
+
+// redact returns the redacted payload (json) value, of the field rules
+func (redactor *AuditRedactor) redact(rules map[string]RedactRuleType, val interface{}) interface{} {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for field, fieldVal := range v {
+			rule, ok := rules[redactFieldName(field)]
+			if !ok {
+				result[field] = redactor.redact(rules, fieldVal)
+				continue
+			}
+			if rule.Action != RedactDrop {
+				result[field] = redactor.redactValue(rule, fieldVal)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, itemVal := range v {
+			result[i] = redactor.redact(rules, itemVal)
+		}
+		return result
+	default:
+		return val
+	}
+}
+
+// RedactPayload method returns the redacted audit-log payload (e.g. log_records) of the table, as the json value
+// (maps, arrays and scalars) of the payload
+func (redactor *AuditRedactor) RedactPayload(tableName string, payload interface{}) interface{} {
+	if payload == nil {
+		return nil
+	}
+	rules := redactor.tableRules(tableName)
+	if len(rules) < 1 {
+		return payload
+	}
+	// the non-json payload is not logged
+	jsonVal, err := json.Marshal(payload)
+	if err != nil {
+		return nil
+	}
+	var jsonPayload interface{}
+	decoder := json.NewDecoder(bytes.NewReader(jsonVal))
+	decoder.UseNumber()
+	if err = decoder.Decode(&jsonPayload); err != nil {
+		return nil
+	}
+	return redactor.redact(rules, jsonPayload)
+}
+
+// RedactDiff method returns the redacted field-level changes of the table
+func (redactor *AuditRedactor) RedactDiff(tableName string, diffs []RecordDiffType) []RecordDiffType {
+	if diffs == nil {
+		return nil
+	}
+	rules := redactor.tableRules(tableName)
+	result := make([]RecordDiffType, 0, len(diffs))
+	for _, diff := range diffs {
+		redactedDiff := RecordDiffType{RecordId: diff.RecordId}
+		for _, change := range diff.Changes {
+			rule, ok := rules[redactFieldName(change.Field)]
+			if !ok {
+				redactedDiff.Changes = append(redactedDiff.Changes, change)
+				continue
+			}
+			if rule.Action == RedactDrop {
+				continue
+			}
+			redactedDiff.Changes = append(redactedDiff.Changes, FieldChangeType{
+				Field:    change.Field,
+				OldValue: redactor.redactValue(rule, change.OldValue),
+				NewValue: redactor.redactValue(rule, change.NewValue),
+			})
+		}
+		if len(redactedDiff.Changes) > 0 {
+			result = append(result, redactedDiff)
+		}
+	}
+	return result
+}
+
+// RedactOptions method returns the audit-log options, with the redacted payloads, query params and changes
+func (redactor *AuditRedactor) RedactOptions(options AuditLogOptionsType) AuditLogOptionsType {
+	options.LogRecords = redactor.RedactPayload(options.TableName, options.LogRecords)
+	options.NewLogRecords = redactor.RedactPayload(options.TableName, options.NewLogRecords)
+	if options.QueryParams != nil {
+		if queryParams, ok := redactor.RedactPayload(options.TableName, options.QueryParams).(map[string]interface{}); ok {
+			options.QueryParams = queryParams
+		}
+	}
+	options.LogDiff = redactor.RedactDiff(options.TableName, options.LogDiff)
+	return options
+}
+
+// RevertPlan method removes the redacted fields of the revert plan updates, as the logged (redacted) values are not
+// the prior record values. The restores (deleted records) are retained, and rejected by the ValidateRevertPlan.
+func (redactor *AuditRedactor) RevertPlan(plan RevertPlanType) RevertPlanType {
+	redactedFields := redactor.RedactedFields(plan.TableName)
+	if len(redactedFields) < 1 {
+		return plan
+	}
+	var updates []RevertRecordType
+	for _, update := range plan.Updates {
+		revertRecord := RevertRecordType{
+			RecordId: update.RecordId,
+			Fields:   map[string]interface{}{},
+			Expected: map[string]interface{}{},
+		}
+		for fieldName, val := range update.Fields {
+			if !ArrayStringContains(redactedFields, redactFieldName(fieldName)) {
+				revertRecord.Fields[fieldName] = val
+				revertRecord.Expected[fieldName] = update.Expected[fieldName]
+			}
+		}
+		if len(revertRecord.Fields) > 0 {
+			updates = append(updates, revertRecord)
+		}
+	}
+	plan.Updates = updates
+	return plan
+}
+
+// RedactAuditLogger redacts the audit-log entries, before the logger (sink) writes
+type RedactAuditLogger struct {
+	Logger   AuditLogger
+	Redactor *AuditRedactor
+}
+
+// NewRedactAuditLogger constructor returns a new RedactAuditLogger instance, of the logger and (default and
+// specified) rules
+func NewRedactAuditLogger(logger AuditLogger, rules []RedactRuleType) RedactAuditLogger {
+	return RedactAuditLogger{
+		Logger:   logger,
+		Redactor: NewAuditRedactor(rules),
+	}
+}
+
+// AuditLog method redacts the entry, and logs the entry by the logger
+func (logger RedactAuditLogger) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	return logger.Logger.AuditLog(logType, userId, logger.Redactor.RedactOptions(options))
+}
@@ -0,0 +1,95 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log payload redaction test cases
+
+package mccrud
+
+import (
+	"encoding/json"
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestAuditRedact(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should redact the default credentials and contact fields, at any depth:",
+		TestFunc: func() {
+			redactor := NewAuditRedactor(nil)
+			payload := redactor.RedactPayload("users", LogRecordsType{
+				LogRecords: ActionParamsType{
+					{"id": "user-1", "loginName": "abi", "password": "secret-pass", "email": "abi@mconnect.biz"},
+				},
+			})
+			mctest.AssertEquals(t, auditPayloadField(payload, "logRecords"), []interface{}{
+				map[string]interface{}{"id": "user-1", "loginName": "abi", "email": "a**@mconnect.biz"},
+			}, "redacted payload records expected")
+			userInfo := redactor.RedactPayload("users", UserInfoType{UserId: "user-1", Token: "token-1", Expire: 9007199254740993})
+			userRecord, _ := userInfo.(map[string]interface{})
+			mctest.AssertEquals(t, userRecord["token"], nil, "dropped token expected")
+			mctest.AssertEquals(t, userRecord["expire"], json.Number("9007199254740993"), "exact number value expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should apply the table rules, overriding the default rules:",
+		TestFunc: func() {
+			redactor := NewAuditRedactor([]RedactRuleType{
+				{TableName: "contacts", Field: "email", Action: RedactKeep},
+				{TableName: "cards", Field: "cardNumber", Action: RedactMask, KeepChars: 4},
+				{TableName: "cards", Field: "holder", Action: RedactTruncate, KeepChars: 3},
+				{Field: "ssn", Action: RedactHash},
+			})
+			mctest.AssertEquals(t, redactor.RedactPayload("contacts", map[string]interface{}{"email": "abi@mconnect.biz"}), map[string]interface{}{"email": "abi@mconnect.biz"}, "kept email expected")
+			mctest.AssertEquals(t, redactor.RedactPayload("cards", map[string]interface{}{"card_number": "4111111111111111", "holder": "Abi Akindele"}), map[string]interface{}{"card_number": "************1111", "holder": "Abi..."}, "masked and truncated fields expected")
+			hashed, _ := redactor.RedactPayload("people", map[string]interface{}{"ssn": "123-45-6789"}).(map[string]interface{})
+			mctest.AssertEquals(t, hashed["ssn"], "sha256:01a54629efb952287e554eb23ef69c52097a75aecc0e3a93ca0855ab6d7a31a0", "hashed ssn expected")
+			redactor.HashKey = []byte("audit-key")
+			keyHashed, _ := redactor.RedactPayload("people", map[string]interface{}{"ssn": "123-45-6789"}).(map[string]interface{})
+			mctest.AssertNotEquals(t, keyHashed["ssn"], hashed["ssn"], "keyed hash expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should redact the entries before the sink writes:",
+		TestFunc: func() {
+			sink := NewMemoryAuditSink()
+			logger := NewRedactAuditLogger(sink, nil)
+			_, err := logger.AuditLog(UpdateLog, "user-1", AuditLogOptionsType{
+				TableName:     "users",
+				LogRecords:    []map[string]interface{}{{"id": "user-1", "password": "old-pass", "firstname": "Abi"}},
+				NewLogRecords: map[string]interface{}{"password": "new-pass", "firstname": "Abbey"},
+				QueryParams:   QueryParamType{"token": "token-1"},
+				LogDiff: []RecordDiffType{{RecordId: "user-1", Changes: []FieldChangeType{
+					{Field: "password", OldValue: "old-pass", NewValue: "new-pass"},
+					{Field: "firstname", OldValue: "Abi", NewValue: "Abbey"},
+				}}},
+			})
+			mctest.AssertEquals(t, err, nil, "audit-log error should be: nil")
+			entries := sink.Entries()
+			mctest.AssertEquals(t, len(entries), 1, "one audit-log entry expected")
+			mctest.AssertEquals(t, entries[0].LogRecords, []interface{}{map[string]interface{}{"id": "user-1", "firstname": "Abi"}}, "redacted log records expected")
+			mctest.AssertEquals(t, entries[0].NewLogRecords, map[string]interface{}{"firstname": "Abbey"}, "redacted new log records expected")
+			mctest.AssertEquals(t, entries[0].LogDiff, []RecordDiffType{{RecordId: "user-1", Changes: []FieldChangeType{
+				{Field: "firstname", OldValue: "Abi", NewValue: "Abbey"},
+			}}}, "redacted changes expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should not revert the redacted fields:",
+		TestFunc: func() {
+			plan := NewAuditRedactor(nil).RevertPlan(RevertPlanType{
+				TableName: "users",
+				Updates: []RevertRecordType{
+					{RecordId: "user-1", Fields: map[string]interface{}{"email": "a**@mconnect.biz", "firstname": "Abi"}, Expected: map[string]interface{}{"email": "a**@mconnect.biz", "firstname": "Abbey"}},
+					{RecordId: "user-2", Fields: map[string]interface{}{"email": "o**@mconnect.biz"}, Expected: map[string]interface{}{"email": "o**@mconnect.biz"}},
+				},
+			})
+			mctest.AssertEquals(t, plan.Updates, []RevertRecordType{
+				{RecordId: "user-1", Fields: map[string]interface{}{"firstname": "Abi"}, Expected: map[string]interface{}{"firstname": "Abbey"}},
+			}, "non-redacted revert fields expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,608 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log retention policies, archival (compressed jsonl files and/or archive table) of the
+// expired entries, and the Postgres time-based (monthly) audit-table partitions
+
+package mccrud
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultAuditArchiveBatch is the default archive (and delete) batch size of the expired entries
+const DefaultAuditArchiveBatch = 1000
+
+// AuditRetentionPolicyType is the retention period of the audit-log entries of the table and log types. The empty
+// TableName or LogTypes matches all the tables or log types.
+type AuditRetentionPolicyType struct {
+	TableName string        `json:"tableName"`
+	LogTypes  []string      `json:"logTypes"`
+	RetainFor time.Duration `json:"retainFor"`
+}
+
+// rank returns the policy specificity: table and log types (3), table (2), log types (1), all entries (0)
+func (policy AuditRetentionPolicyType) rank() int {
+	rank := 0
+	if policy.TableName != "" {
+		rank += 2
+	}
+	if len(policy.LogTypes) > 0 {
+		rank++
+	}
+	return rank
+}
+
+// scope returns the policy table and log types condition and values, from the value placeholder position
+func (policy AuditRetentionPolicyType) scope(position int) (string, []interface{}) {
+	var conditions []string
+	var values []interface{}
+	if policy.TableName != "" {
+		values = append(values, policy.TableName)
+		conditions = append(conditions, fmt.Sprintf("table_name=$%v", position+len(values)))
+	}
+	if len(policy.LogTypes) > 0 {
+		var logTypes []string
+		for _, logType := range policy.LogTypes {
+			logTypes = append(logTypes, strings.ToLower(logType))
+		}
+		conditions = append(conditions, fmt.Sprintf("log_type IN (%v)", ArrayToSQLStringValues(logTypes)))
+	}
+	if len(conditions) < 1 {
+		return "TRUE", values
+	}
+	return strings.Join(conditions, " AND "), values
+}
+
+// AuditRetentionScopeType is the where-script and values of the expired entries of a retention policy
+type AuditRetentionScopeType struct {
+	Policy      AuditRetentionPolicyType
+	Cutoff      time.Time // log_at < cutoff
+	WhereScript string
+	Values      []interface{}
+}
+
+// ValidateRetentionPolicies function validates the retention periods
+func ValidateRetentionPolicies(policies []AuditRetentionPolicyType) error {
+	var errorMessages []string
+	for i, policy := range policies {
+		if policy.RetainFor <= 0 {
+			errorMessages = append(errorMessages, fmt.Sprintf("Retention period of policy %v (table: %v, log types: %v) is required.", i+1, policy.TableName, policy.LogTypes))
+		}
+	}
+	if len(errorMessages) > 0 {
+		return errors.New(strings.Join(errorMessages, " | "))
+	}
+	return nil
+}
+
+// ComputeRetentionScopes function computes the expired entries where-scripts of the policies, as of now. An entry
+// is governed by its most specific matching policy (table and log types, table, log types, then all entries), and by
+// the first of the equally specific policies. The entries without a matching policy are retained.
+func ComputeRetentionScopes(policies []AuditRetentionPolicyType, now time.Time) []AuditRetentionScopeType {
+	scopes := computeRetentionScopes(policies, now, 0)
+	for i := range scopes {
+		scopes[i].WhereScript = " WHERE " + scopes[i].WhereScript
+	}
+	return scopes
+}
+
+// computeRetentionScopes computes the expired entries conditions of the policies, from the value placeholder position
+func computeRetentionScopes(policies []AuditRetentionPolicyType, now time.Time, position int) []AuditRetentionScopeType {
+	var scopes []AuditRetentionScopeType
+	for i := range policies {
+		scopes = append(scopes, policyRetentionScope(policies, i, now, position))
+	}
+	return scopes
+}
+
+// policyRetentionScope computes the expired entries condition of the policy (index), from the value placeholder
+// position
+func policyRetentionScope(policies []AuditRetentionPolicyType, i int, now time.Time, position int) AuditRetentionScopeType {
+	policy := policies[i]
+	scopeScript, values := policy.scope(position)
+	conditions := []string{scopeScript}
+	// the entries governed by the more specific, or prior equally specific, policies
+	for j, other := range policies {
+		if j == i || other.rank() < policy.rank() || (other.rank() == policy.rank() && j > i) {
+			continue
+		}
+		otherScript, otherValues := other.scope(position + len(values))
+		values = append(values, otherValues...)
+		conditions = append(conditions, fmt.Sprintf("NOT (%v)", otherScript))
+	}
+	cutoff := now.Add(-policy.RetainFor)
+	values = append(values, cutoff)
+	conditions = append(conditions, fmt.Sprintf("log_at<$%v", position+len(values)))
+	return AuditRetentionScopeType{
+		Policy:      policy,
+		Cutoff:      cutoff,
+		WhereScript: strings.Join(conditions, " AND "),
+		Values:      values,
+	}
+}
+
+// retentionExpiredScript returns the expired entries condition (of any policy) and values, from the value
+// placeholder position
+func retentionExpiredScript(policies []AuditRetentionPolicyType, now time.Time, position int) (string, []interface{}) {
+	var (
+		conditions []string
+		values     []interface{}
+	)
+	for i := range policies {
+		scope := policyRetentionScope(policies, i, now, position+len(values))
+		conditions = append(conditions, "("+scope.WhereScript+")")
+		values = append(values, scope.Values...)
+	}
+	if len(conditions) < 1 {
+		return "FALSE", values
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", values
+}
+
+// ComputeChainRetentionScope function computes the expired hash-chained entries where-script of the policies, as of
+// now: the expired chain prefix, by sequence, before the first retained entry of the chain. The chained entries after
+// a retained entry are retained, as the chain verification starts from the last archived entry (the chain anchor).
+func ComputeChainRetentionScope(auditTable string, policies []AuditRetentionPolicyType, now time.Time) AuditRetentionScopeType {
+	expiredScript, values := retentionExpiredScript(policies, now, 0)
+	retainedScript, retainedValues := retentionExpiredScript(policies, now, len(values))
+	values = append(values, retainedValues...)
+	// the unqualified fields of the sub-query are of the retained (r) entries
+	whereScript := fmt.Sprintf(" WHERE chain_key IS NOT NULL AND %v AND chain_seq<COALESCE((SELECT MIN(r.chain_seq) FROM %v r WHERE r.chain_key=%v.chain_key AND NOT %v), chain_seq+1)", expiredScript, auditTable, auditTable, retainedScript)
+	return AuditRetentionScopeType{
+		WhereScript: whereScript,
+		Values:      values,
+	}
+}
+
+// AuditChainAnchors function returns the last archived sequence and hash (chain anchor), by chain key, of the
+// archived records, ordered by chain key and sequence
+func AuditChainAnchors(records []map[string]interface{}) []AuditChainHeadType {
+	var anchors []AuditChainHeadType
+	for _, record := range records {
+		chainKey, ok := record["chain_key"].(string)
+		if !ok || chainKey == "" {
+			continue
+		}
+		var chainSeq int64
+		switch seq := record["chain_seq"].(type) {
+		case int64:
+			chainSeq = seq
+		case int:
+			chainSeq = int64(seq)
+		case float64:
+			chainSeq = int64(seq)
+		case string:
+			chainSeq, _ = strconv.ParseInt(seq, 10, 64)
+		}
+		hash, _ := record["hash"].(string)
+		anchor := AuditChainHeadType{ChainKey: chainKey, ArchivedSeq: chainSeq, ArchivedHash: hash}
+		if len(anchors) > 0 && anchors[len(anchors)-1].ChainKey == chainKey {
+			anchors[len(anchors)-1] = anchor
+			continue
+		}
+		anchors = append(anchors, anchor)
+	}
+	return anchors
+}
+
+// AuditRetentionResultType is the audit-log maintenance result
+type AuditRetentionResultType struct {
+	Archived            int      `json:"archived"` // entries archived to the archive table
+	Deleted             int      `json:"deleted"`
+	ArchiveFiles        []string `json:"archiveFiles"`
+	CreatedPartitions   []string `json:"createdPartitions"`
+	DroppedPartitions   []string `json:"droppedPartitions"`
+	RetainedPartitions  []string `json:"retainedPartitions"` // expired, non-empty partitions
+	PartitionsSupported bool     `json:"partitionsSupported"`
+}
+
+// AuditRetention archives and deletes the expired audit-log entries, of the retention policies. The expired entries
+// are archived, by batch, to the compressed (gzip) jsonl files of the ArchiveDir and/or to the ArchiveTable (the
+// audit-table columns), and deleted, in the batch transaction. The hash-chained entries are archived as the expired
+// chain prefix only, and the last archived entry sequence and hash (the chain anchor) are stored in the ChainTable,
+// for the chain verification.
+type AuditRetention struct {
+	AuditDb      *sqlx.DB
+	AuditTable   string
+	Policies     []AuditRetentionPolicyType
+	HashChain    bool   // hash-chained audit-table entries (chain_key, chain_seq, prev_hash and hash columns)
+	ChainTable   string // hash-chain heads table, default: audit_chains
+	ArchiveDir   string // compressed jsonl files directory, if specified
+	ArchiveTable string // archive table, if specified
+	BatchSize    int    // default: DefaultAuditArchiveBatch
+	// Postgres monthly partitions of the audit table (PARTITION BY RANGE (log_at)), if specified
+	Partitioned       bool
+	PartitionsAhead   int // the next months partitions created, default: 2
+	DropOldPartitions bool
+}
+
+// NewAuditRetention constructor returns a new AuditRetention instance, of the audit db, table and policies
+func NewAuditRetention(auditDb *sqlx.DB, auditTable string, policies []AuditRetentionPolicyType) *AuditRetention {
+	retention := &AuditRetention{
+		AuditDb:    auditDb,
+		AuditTable: auditTable,
+		Policies:   policies,
+	}
+	// default values
+	if retention.AuditTable == "" {
+		retention.AuditTable = "audits"
+	}
+	retention.ChainTable = "audit_chains"
+	return retention
+}
+
+// AuditRetention method returns the AuditRetention of the crud audit-db, audit-table and hash-chain
+func (crud *Crud) AuditRetention(policies []AuditRetentionPolicyType) *AuditRetention {
+	retention := NewAuditRetention(crud.AuditDb, crud.AuditTable, policies)
+	retention.HashChain = crud.AuditHashChain
+	if crud.AuditChainTable != "" {
+		retention.ChainTable = crud.AuditChainTable
+	}
+	return retention
+}
+
+// batchSize returns the archive batch size
+func (retention *AuditRetention) batchSize() int {
+	if retention.BatchSize <= 0 {
+		return DefaultAuditArchiveBatch
+	}
+	return retention.BatchSize
+}
+
+// Run method performs the audit-log maintenance, as of now: the partitions creation, the expired entries archival
+// and deletion, and the expired (empty) partitions removal
+func (retention *AuditRetention) Run(now time.Time) mcresponse.ResponseMessage {
+	var result AuditRetentionResultType
+	if err := ValidateRetentionPolicies(retention.Policies); err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	if retention.Partitioned {
+		result.PartitionsSupported = retention.AuditDb.DriverName() == "postgres"
+		if result.PartitionsSupported {
+			created, err := retention.CreatePartitions(now)
+			result.CreatedPartitions = created
+			if err != nil {
+				return retentionErrorResponse(result, err)
+			}
+		}
+	}
+	scopes := ComputeRetentionScopes(retention.Policies, now)
+	if retention.HashChain {
+		// the unchained entries, by policy, and the expired chains prefixes, by chain sequence
+		for i := range scopes {
+			scopes[i].WhereScript += " AND chain_key IS NULL"
+		}
+	}
+	var archiveFile *auditArchiveFile
+	if retention.ArchiveDir != "" {
+		archiveFile = &auditArchiveFile{
+			FilePath: filepath.Join(retention.ArchiveDir, fmt.Sprintf("%v-%v.jsonl.gz", retention.AuditTable, now.UTC().Format("20060102T150405"))),
+		}
+		defer func() {
+			_ = archiveFile.Close()
+		}()
+	}
+	archiveScope := func(scope AuditRetentionScopeType, orderBy string) error {
+		for {
+			archived, deleted, err := retention.archiveBatch(scope, orderBy, archiveFile)
+			result.Archived += archived
+			result.Deleted += deleted
+			if err != nil {
+				return err
+			}
+			if deleted < retention.batchSize() {
+				return nil
+			}
+		}
+	}
+	for _, scope := range scopes {
+		if err := archiveScope(scope, "log_at, id"); err != nil {
+			return retentionErrorResponse(result, err)
+		}
+	}
+	if retention.HashChain {
+		if err := archiveScope(ComputeChainRetentionScope(retention.AuditTable, retention.Policies, now), "chain_key, chain_seq"); err != nil {
+			return retentionErrorResponse(result, err)
+		}
+	}
+	if archiveFile != nil && archiveFile.file != nil {
+		if err := archiveFile.Close(); err != nil {
+			return retentionErrorResponse(result, err)
+		}
+		result.ArchiveFiles = append(result.ArchiveFiles, archiveFile.FilePath)
+	}
+	if retention.Partitioned && result.PartitionsSupported && retention.DropOldPartitions {
+		dropped, retained, err := retention.DropPartitions(now)
+		result.DroppedPartitions = dropped
+		result.RetainedPartitions = retained
+		if err != nil {
+			return retentionErrorResponse(result, err)
+		}
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v expired audit-log entries deleted, %v archived to the archive table", result.Deleted, result.Archived),
+		Value:   result,
+	})
+}
+
+// retentionErrorResponse returns the maintenance error response, with the completed (partial) result
+func retentionErrorResponse(result AuditRetentionResultType, err error) mcresponse.ResponseMessage {
+	return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Error performing audit-log maintenance: %v", err.Error()),
+		Value:   result,
+	})
+}
+
+// archiveBatch archives and deletes a batch of the expired entries of the scope, by order, in a transaction, and
+// stores the archived chains anchors. The archive file batch is written before the transaction commit; a failed
+// commit batch is archived again, by the next run.
+func (retention *AuditRetention) archiveBatch(scope AuditRetentionScopeType, orderBy string, archiveFile *auditArchiveFile) (int, int, error) {
+	tx, err := retention.AuditDb.Beginx()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+	selectScript := fmt.Sprintf("SELECT * FROM %v%v ORDER BY %v LIMIT %v", retention.AuditTable, scope.WhereScript, orderBy, retention.batchSize())
+	rows, err := tx.Queryx(selectScript, scope.Values...)
+	if err != nil {
+		return 0, 0, err
+	}
+	var (
+		records []map[string]interface{}
+		ids     []string
+	)
+	for rows.Next() {
+		record := map[string]interface{}{}
+		if err = rows.MapScan(record); err != nil {
+			_ = rows.Close()
+			return 0, 0, err
+		}
+		for key, val := range record {
+			if bytesVal, ok := val.([]byte); ok {
+				record[key] = string(bytesVal)
+			}
+		}
+		records = append(records, record)
+		ids = append(ids, fmt.Sprintf("%v", record["id"]))
+	}
+	_ = rows.Close()
+	if err = rows.Err(); err != nil {
+		return 0, 0, err
+	}
+	if len(ids) < 1 {
+		return 0, 0, nil
+	}
+	idsScript := ArrayToSQLStringValues(ids)
+	archived := 0
+	if retention.ArchiveTable != "" {
+		archiveScript := fmt.Sprintf("INSERT INTO %v SELECT * FROM %v WHERE id IN (%v)", retention.ArchiveTable, retention.AuditTable, idsScript)
+		archiveResult, err := tx.Exec(archiveScript)
+		if err != nil {
+			return 0, 0, err
+		}
+		archivedCount, _ := archiveResult.RowsAffected()
+		archived = int(archivedCount)
+	}
+	if archiveFile != nil {
+		if err = archiveFile.Write(records); err != nil {
+			return 0, 0, err
+		}
+	}
+	deleteResult, err := tx.Exec(fmt.Sprintf("DELETE FROM %v WHERE id IN (%v)", retention.AuditTable, idsScript))
+	if err != nil {
+		return 0, 0, err
+	}
+	deletedCount, _ := deleteResult.RowsAffected()
+	if retention.HashChain {
+		anchorScript := fmt.Sprintf("UPDATE %v SET archived_seq=$1, archived_hash=$2 WHERE chain_key=$3 AND archived_seq<$1", retention.ChainTable)
+		for _, anchor := range AuditChainAnchors(records) {
+			if _, err = tx.Exec(anchorScript, anchor.ArchivedSeq, anchor.ArchivedHash, anchor.ChainKey); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, 0, err
+	}
+	return archived, int(deletedCount), nil
+}
+
+// auditArchiveFile is the compressed (gzip) jsonl archive file, created on the first write
+type auditArchiveFile struct {
+	FilePath string
+	file     *os.File
+	writer   *gzip.Writer
+}
+
+// Write method appends the records json lines, and flushes the compressed batch
+func (archive *auditArchiveFile) Write(records []map[string]interface{}) error {
+	if archive.file == nil {
+		if err := os.MkdirAll(filepath.Dir(archive.FilePath), 0700); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(archive.FilePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		archive.file = file
+		archive.writer = gzip.NewWriter(file)
+	}
+	for _, record := range records {
+		jsonVal, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		if _, err = archive.writer.Write(append(jsonVal, '\n')); err != nil {
+			return err
+		}
+	}
+	return archive.writer.Flush()
+}
+
+// Close method completes and closes the archive file
+func (archive *auditArchiveFile) Close() error {
+	if archive.file == nil || archive.writer == nil {
+		return nil
+	}
+	err := archive.writer.Close()
+	archive.writer = nil
+	if closeErr := archive.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// ReadAuditArchive function returns the archived records of the compressed jsonl archive file
+func ReadAuditArchive(filePath string) ([]map[string]interface{}, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func(file *os.File) {
+		_ = file.Close()
+	}(file)
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, err
+	}
+	defer func(reader *gzip.Reader) {
+		_ = reader.Close()
+	}(reader)
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(reader)
+	for decoder.More() {
+		record := map[string]interface{}{}
+		if err = decoder.Decode(&record); err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// auditPartitionPattern is the monthly partition name pattern, of the AuditPartitionName
+var auditPartitionPattern = regexp.MustCompile(`_y(\d{4})m(\d{2})$`)
+
+// AuditPartitionName function returns the monthly partition name of the audit table and month, e.g. audits_y2026m10
+func AuditPartitionName(auditTable string, month time.Time) string {
+	return fmt.Sprintf("%v_y%04dm%02d", auditTable, month.Year(), int(month.Month()))
+}
+
+// AuditPartitionRange function returns the monthly partition range [from, to) of the time, in UTC
+func AuditPartitionRange(at time.Time) (time.Time, time.Time) {
+	at = at.UTC()
+	from := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return from, from.AddDate(0, 1, 0)
+}
+
+// auditPartitionMonth returns the partition month (range start) of the partition name
+func auditPartitionMonth(partitionName string) (time.Time, bool) {
+	matches := auditPartitionPattern.FindStringSubmatch(partitionName)
+	if matches == nil {
+		return time.Time{}, false
+	}
+	month, err := time.Parse("2006-01", matches[1]+"-"+matches[2])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return month, true
+}
+
+// AuditPartitionScript function returns the monthly partition create script of the audit table and month
+func AuditPartitionScript(auditTable string, month time.Time) string {
+	from, to := AuditPartitionRange(month)
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v PARTITION OF %v FOR VALUES FROM ('%v') TO ('%v')", AuditPartitionName(auditTable, from), auditTable, from.Format(time.RFC3339), to.Format(time.RFC3339))
+}
+
+// CreatePartitions method creates the current and the next (PartitionsAhead) months partitions, as of now
+func (retention *AuditRetention) CreatePartitions(now time.Time) ([]string, error) {
+	ahead := retention.PartitionsAhead
+	if ahead <= 0 {
+		ahead = 2
+	}
+	current, _ := AuditPartitionRange(now)
+	var created []string
+	for i := 0; i <= ahead; i++ {
+		month := current.AddDate(0, i, 0)
+		if _, err := retention.AuditDb.Exec(AuditPartitionScript(retention.AuditTable, month)); err != nil {
+			return created, err
+		}
+		created = append(created, AuditPartitionName(retention.AuditTable, month))
+	}
+	return created, nil
+}
+
+// partitions returns the audit-table partition names
+func (retention *AuditRetention) partitions() ([]string, error) {
+	partitionScript := "SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid=i.inhrelid JOIN pg_class p ON p.oid=i.inhparent WHERE p.relname=$1 ORDER BY c.relname"
+	var partitions []string
+	if err := retention.AuditDb.Select(&partitions, partitionScript, retention.AuditTable); err != nil {
+		return nil, err
+	}
+	return partitions, nil
+}
+
+// DropPartitions method drops the monthly partitions of the expired (archived and deleted) months, as of now. A
+// partition month is expired, if it is before the earliest cutoff of all the policies, and the policies include an
+// all-entries policy. The non-empty expired partitions are retained and reported.
+func (retention *AuditRetention) DropPartitions(now time.Time) ([]string, []string, error) {
+	var (
+		cutoff   time.Time
+		catchAll bool
+		dropped  []string
+		retained []string
+	)
+	for _, scope := range ComputeRetentionScopes(retention.Policies, now) {
+		if scope.Policy.rank() == 0 {
+			catchAll = true
+		}
+		if cutoff.IsZero() || scope.Cutoff.Before(cutoff) {
+			cutoff = scope.Cutoff
+		}
+	}
+	if !catchAll {
+		return dropped, retained, nil
+	}
+	partitions, err := retention.partitions()
+	if err != nil {
+		return dropped, retained, err
+	}
+	for _, partition := range partitions {
+		month, ok := auditPartitionMonth(partition)
+		if !ok {
+			continue
+		}
+		if _, to := AuditPartitionRange(month); to.After(cutoff) {
+			continue
+		}
+		var exists bool
+		if err = retention.AuditDb.QueryRow(fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %v)", partition)).Scan(&exists); err != nil {
+			return dropped, retained, err
+		}
+		if exists {
+			retained = append(retained, partition)
+			continue
+		}
+		if _, err = retention.AuditDb.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %v", partition)); err != nil {
+			return dropped, retained, err
+		}
+		dropped = append(dropped, partition)
+	}
+	return dropped, retained, nil
+}
@@ -0,0 +1,106 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log retention, archive and partitions test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAuditRetention(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the expired entries scopes, by the most specific policy:",
+		TestFunc: func() {
+			scopes := ComputeRetentionScopes([]AuditRetentionPolicyType{
+				{RetainFor: 7 * 365 * day},
+				{LogTypes: []string{"Read", GetLog}, RetainFor: 30 * day},
+				{TableName: "users", RetainFor: 365 * day},
+			}, now)
+			mctest.AssertEquals(t, len(scopes), 3, "three retention scopes expected")
+			mctest.AssertEquals(t, scopes[0].WhereScript, " WHERE TRUE AND NOT (log_type IN ('read', 'get')) AND NOT (table_name=$1) AND log_at<$2", "all-entries scope expected")
+			mctest.AssertEquals(t, scopes[0].Values, []interface{}{"users", now.Add(-7 * 365 * day)}, "all-entries values expected")
+			mctest.AssertEquals(t, scopes[1].WhereScript, " WHERE log_type IN ('read', 'get') AND NOT (table_name=$1) AND log_at<$2", "log types scope expected")
+			mctest.AssertEquals(t, scopes[2].WhereScript, " WHERE table_name=$1 AND log_at<$2", "table scope expected")
+			mctest.AssertEquals(t, scopes[2].Cutoff, now.Add(-365*day), "table cutoff expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the expired chain prefix scope, of all the policies:",
+		TestFunc: func() {
+			scope := ComputeChainRetentionScope("audits", []AuditRetentionPolicyType{
+				{RetainFor: 365 * day},
+				{TableName: "users", RetainFor: 30 * day},
+			}, now)
+			expired := "((TRUE AND NOT (table_name=$1) AND log_at<$2) OR (table_name=$3 AND log_at<$4))"
+			retained := "((TRUE AND NOT (table_name=$5) AND log_at<$6) OR (table_name=$7 AND log_at<$8))"
+			mctest.AssertEquals(t, scope.WhereScript, " WHERE chain_key IS NOT NULL AND "+expired+" AND chain_seq<COALESCE((SELECT MIN(r.chain_seq) FROM audits r WHERE r.chain_key=audits.chain_key AND NOT "+retained+"), chain_seq+1)", "chain prefix scope expected")
+			values := []interface{}{"users", now.Add(-365 * day), "users", now.Add(-30 * day)}
+			mctest.AssertEquals(t, scope.Values, append(values, values...), "chain prefix values expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the last archived entry anchor, by chain:",
+		TestFunc: func() {
+			anchors := AuditChainAnchors([]map[string]interface{}{
+				{"chain_key": "groups", "chain_seq": int64(1), "hash": "h-1"},
+				{"chain_key": "groups", "chain_seq": int64(2), "hash": "h-2"},
+				{"chain_key": nil, "chain_seq": nil, "hash": nil},
+				{"chain_key": "users", "chain_seq": int64(7), "hash": "u-7"},
+			})
+			mctest.AssertEquals(t, anchors, []AuditChainHeadType{
+				{ChainKey: "groups", ArchivedSeq: 2, ArchivedHash: "h-2"},
+				{ChainKey: "users", ArchivedSeq: 7, ArchivedHash: "u-7"},
+			}, "chain anchors expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should require the retention periods:",
+		TestFunc: func() {
+			err := ValidateRetentionPolicies([]AuditRetentionPolicyType{{TableName: "users"}})
+			mctest.AssertNotEquals(t, err, nil, "retention period required error expected")
+			res := NewAuditRetention(nil, "", []AuditRetentionPolicyType{{TableName: "users"}}).Run(now)
+			mctest.AssertEquals(t, res.Code, "paramsError", "maintenance response-code should be: paramsError")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the monthly partitions:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, AuditPartitionName("audits", now), "audits_y2026m10", "partition name expected")
+			mctest.AssertEquals(t, AuditPartitionScript("audits", time.Date(2026, 12, 5, 0, 0, 0, 0, time.UTC)), "CREATE TABLE IF NOT EXISTS audits_y2026m12 PARTITION OF audits FOR VALUES FROM ('2026-12-01T00:00:00Z') TO ('2027-01-01T00:00:00Z')", "partition script expected")
+			month, ok := auditPartitionMonth("audits_y2025m02")
+			mctest.AssertEquals(t, ok, true, "partition month expected")
+			mctest.AssertEquals(t, month, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), "partition month value expected")
+			_, ok = auditPartitionMonth("audits_default")
+			mctest.AssertEquals(t, ok, false, "non-monthly partition expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should write and read the compressed archive records:",
+		TestFunc: func() {
+			archive := &auditArchiveFile{FilePath: filepath.Join(t.TempDir(), "archive", "audits.jsonl.gz")}
+			mctest.AssertEquals(t, archive.Write([]map[string]interface{}{{"id": "a-1", "log_type": "read"}}), nil, "archive write error should be: nil")
+			mctest.AssertEquals(t, archive.Write([]map[string]interface{}{{"id": "a-2", "log_type": "create"}}), nil, "archive write error should be: nil")
+			mctest.AssertEquals(t, archive.Close(), nil, "archive close error should be: nil")
+			records, err := ReadAuditArchive(archive.FilePath)
+			mctest.AssertEquals(t, err, nil, "archive read error should be: nil")
+			mctest.AssertEquals(t, records, []map[string]interface{}{
+				{"id": "a-1", "log_type": "read"},
+				{"id": "a-2", "log_type": "create"},
+			}, "archived records expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
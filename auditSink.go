@@ -0,0 +1,281 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log sinks (AuditLogger): jsonl files with rotation, writer/stdout, in-memory and fan-out.
+// The SQL audit-table sinks are LogParam (database/sql) and LogParamX (sqlx), by the AuditEngine.
+
+package mccrud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditEntryType is the audit-log entry of the non-SQL sinks
+type AuditEntryType struct {
+	LogType       string           `json:"logType"`
+	TableName     string           `json:"tableName"`
+	LogRecords    interface{}      `json:"logRecords"`
+	NewLogRecords interface{}      `json:"newLogRecords,omitempty"`
+	LogDiff       []RecordDiffType `json:"logDiff,omitempty"`
+	RecordIds     []string         `json:"recordIds,omitempty"`
+	LogBy         string           `json:"logBy"`
+	LogAt         time.Time        `json:"logAt"`
+	AuditContext  AuditContextType `json:"auditContext"`
+}
+
+// NewAuditEntry function validates (ValidateAuditLog) and returns the audit-log entry of the log type, user and
+// options
+func NewAuditEntry(logType, userId string, options AuditLogOptionsType) (AuditEntryType, error) {
+	logType = strings.ToLower(logType)
+	if err := ValidateAuditLog(logType, userId, options); err != nil {
+		return AuditEntryType{}, err
+	}
+	entry := AuditEntryType{
+		LogType:    logType,
+		TableName:  options.TableName,
+		LogRecords: options.LogRecords,
+		RecordIds:  options.RecordIds,
+		LogBy:      userId,
+		LogAt:      options.LogAt,
+	}
+	entry.AuditContext = options.AuditContext
+	if logType == UpdateLog {
+		entry.NewLogRecords = options.NewLogRecords
+		entry.LogDiff = options.LogDiff
+	}
+	if entry.LogAt.IsZero() {
+		entry.LogAt = time.Now()
+	}
+	return entry, nil
+}
+
+// auditSinkResponse returns the sink response message of the write error or entry
+func auditSinkResponse(entry AuditEntryType, err error) (mcresponse.ResponseMessage, error) {
+	if err != nil {
+		return mcresponse.GetResMessage("logError",
+			mcresponse.ResponseMessageOptions{
+				Message: err.Error(),
+				Value:   nil,
+			}), err
+	}
+	return mcresponse.GetResMessage("success",
+		mcresponse.ResponseMessageOptions{
+			Message: "successful audit-log action",
+			Value:   entry,
+		}), nil
+}
+
+// MemoryAuditSink keeps the audit-log entries in memory, e.g. for tests
+type MemoryAuditSink struct {
+	entries []AuditEntryType
+	mutex   sync.RWMutex
+}
+
+// NewMemoryAuditSink constructor returns a new MemoryAuditSink instance
+func NewMemoryAuditSink() *MemoryAuditSink {
+	return &MemoryAuditSink{}
+}
+
+// AuditLog method appends the audit-log entry
+func (sink *MemoryAuditSink) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	entry, err := NewAuditEntry(logType, userId, options)
+	if err == nil {
+		sink.mutex.Lock()
+		sink.entries = append(sink.entries, entry)
+		sink.mutex.Unlock()
+	}
+	return auditSinkResponse(entry, err)
+}
+
+// Entries method returns the audit-log entries, in the log order
+func (sink *MemoryAuditSink) Entries() []AuditEntryType {
+	sink.mutex.RLock()
+	defer sink.mutex.RUnlock()
+	entries := make([]AuditEntryType, len(sink.entries))
+	copy(entries, sink.entries)
+	return entries
+}
+
+// Reset method removes the audit-log entries
+func (sink *MemoryAuditSink) Reset() {
+	sink.mutex.Lock()
+	sink.entries = nil
+	sink.mutex.Unlock()
+}
+
+// WriterAuditSink writes the audit-log entries as json lines (structured logs), to the writer
+type WriterAuditSink struct {
+	Writer io.Writer
+	mutex  sync.Mutex
+}
+
+// NewWriterAuditSink constructor returns a new WriterAuditSink instance, of the writer
+func NewWriterAuditSink(writer io.Writer) *WriterAuditSink {
+	return &WriterAuditSink{Writer: writer}
+}
+
+// NewStdoutAuditSink constructor returns a new WriterAuditSink instance, of the standard output
+func NewStdoutAuditSink() *WriterAuditSink {
+	return NewWriterAuditSink(os.Stdout)
+}
+
+// AuditLog method writes the audit-log entry json line
+func (sink *WriterAuditSink) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	entry, err := NewAuditEntry(logType, userId, options)
+	if err != nil {
+		return auditSinkResponse(entry, err)
+	}
+	jsonVal, err := json.Marshal(entry)
+	if err == nil {
+		sink.mutex.Lock()
+		_, err = sink.Writer.Write(append(jsonVal, '\n'))
+		sink.mutex.Unlock()
+	}
+	return auditSinkResponse(entry, err)
+}
+
+// JsonlAuditSink appends the audit-log entries as json lines, to the file. The file is rotated, by size, to the
+// numbered files (file.1 the most recent), up to MaxFiles.
+type JsonlAuditSink struct {
+	FilePath string
+	MaxSize  int64 // rotation size in bytes, default: 10MB
+	MaxFiles int   // rotated files kept, default: 5
+	file     *os.File
+	size     int64
+	mutex    sync.Mutex
+}
+
+// NewJsonlAuditSink constructor returns a new JsonlAuditSink instance, of the file path
+func NewJsonlAuditSink(filePath string, maxSize int64, maxFiles int) *JsonlAuditSink {
+	sink := &JsonlAuditSink{
+		FilePath: filePath,
+		MaxSize:  maxSize,
+		MaxFiles: maxFiles,
+	}
+	// default values
+	if sink.FilePath == "" {
+		sink.FilePath = "audits.jsonl"
+	}
+	if sink.MaxSize <= 0 {
+		sink.MaxSize = 10 * 1024 * 1024
+	}
+	if sink.MaxFiles <= 0 {
+		sink.MaxFiles = 5
+	}
+	return sink
+}
+
+// open opens the (append-only) file, and its current size
+func (sink *JsonlAuditSink) open() error {
+	file, err := os.OpenFile(sink.FilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	sink.file = file
+	sink.size = info.Size()
+	return nil
+}
+
+// rotate closes the file, and shifts the numbered files, removing the oldest
+func (sink *JsonlAuditSink) rotate() error {
+	if sink.file != nil {
+		if err := sink.file.Close(); err != nil {
+			return err
+		}
+		sink.file = nil
+	}
+	_ = os.Remove(fmt.Sprintf("%v.%v", sink.FilePath, sink.MaxFiles))
+	for i := sink.MaxFiles - 1; i > 0; i-- {
+		if err := os.Rename(fmt.Sprintf("%v.%v", sink.FilePath, i), fmt.Sprintf("%v.%v", sink.FilePath, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(sink.FilePath, sink.FilePath+".1"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return sink.open()
+}
+
+// AuditLog method appends the audit-log entry json line, and rotates the file, if the entry exceeds the max size
+func (sink *JsonlAuditSink) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	entry, err := NewAuditEntry(logType, userId, options)
+	if err != nil {
+		return auditSinkResponse(entry, err)
+	}
+	jsonVal, err := json.Marshal(entry)
+	if err != nil {
+		return auditSinkResponse(entry, err)
+	}
+	jsonVal = append(jsonVal, '\n')
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.file == nil {
+		err = sink.open()
+	}
+	if err == nil && sink.size > 0 && sink.size+int64(len(jsonVal)) > sink.MaxSize {
+		err = sink.rotate()
+	}
+	if err == nil {
+		var count int
+		count, err = sink.file.Write(jsonVal)
+		sink.size += int64(count)
+	}
+	return auditSinkResponse(entry, err)
+}
+
+// Close method closes the file
+func (sink *JsonlAuditSink) Close() error {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	if sink.file == nil {
+		return nil
+	}
+	err := sink.file.Close()
+	sink.file = nil
+	return err
+}
+
+// FanOutAuditSink writes the audit-log entries to all the sinks
+type FanOutAuditSink struct {
+	Sinks []AuditLogger
+}
+
+// NewFanOutAuditSink constructor returns a new FanOutAuditSink instance, of the sinks
+func NewFanOutAuditSink(sinks ...AuditLogger) *FanOutAuditSink {
+	return &FanOutAuditSink{Sinks: sinks}
+}
+
+// AuditLog method writes the audit-log entry to all the sinks. The sinks failures are combined.
+func (sink *FanOutAuditSink) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	var errorMessages []string
+	for _, auditSink := range sink.Sinks {
+		if _, err := auditSink.AuditLog(logType, userId, options); err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+	}
+	if len(errorMessages) > 0 {
+		errMsg := fmt.Sprintf("%v of %v audit-log sink(s) failed: %v", len(errorMessages), len(sink.Sinks), strings.Join(errorMessages, " | "))
+		return mcresponse.GetResMessage("logError",
+			mcresponse.ResponseMessageOptions{
+				Message: errMsg,
+				Value:   nil,
+			}), errors.New(errMsg)
+	}
+	return mcresponse.GetResMessage("success",
+		mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("successful audit-log action, %v sink(s)", len(sink.Sinks)),
+			Value:   nil,
+		}), nil
+}
@@ -0,0 +1,64 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log sinks test cases
+
+package mccrud
+
+import (
+	"bytes"
+	"github.com/abbeymart/mctest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAuditSink(t *testing.T) {
+	options := AuditLogOptionsType{
+		TableName:  "groups",
+		LogRecords: map[string]interface{}{"id": "rec-1", "name": "Group"},
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should fan-out the audit-log entries to the memory and writer sinks:",
+		TestFunc: func() {
+			memorySink := NewMemoryAuditSink()
+			var buffer bytes.Buffer
+			sink := NewFanOutAuditSink(memorySink, NewWriterAuditSink(&buffer))
+			res, err := sink.AuditLog(CreateLog, "user-1", options)
+			mctest.AssertEquals(t, err, nil, "no error expected")
+			mctest.AssertEquals(t, res.Code, "success", "success response expected")
+			entries := memorySink.Entries()
+			mctest.AssertEquals(t, len(entries), 1, "one memory entry expected")
+			mctest.AssertEquals(t, entries[0].LogType, CreateLog, "create log type expected")
+			mctest.AssertEquals(t, entries[0].LogBy, "user-1", "logBy expected")
+			mctest.AssertEquals(t, strings.Count(buffer.String(), "\n"), 1, "one json line expected")
+			_, err = sink.AuditLog(CreateLog, "", options)
+			mctest.AssertNotEquals(t, err, nil, "userId required error expected")
+			mctest.AssertEquals(t, len(memorySink.Entries()), 1, "invalid entry should not be logged")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should append and rotate the jsonl audit-log files:",
+		TestFunc: func() {
+			filePath := filepath.Join(t.TempDir(), "audits.jsonl")
+			sink := NewJsonlAuditSink(filePath, 500, 2)
+			for i := 0; i < 6; i++ {
+				_, err := sink.AuditLog(CreateLog, "user-1", options)
+				mctest.AssertEquals(t, err, nil, "no error expected")
+			}
+			mctest.AssertEquals(t, sink.Close(), nil, "no close error expected")
+			_, err := os.Stat(filePath + ".1")
+			mctest.AssertEquals(t, err, nil, "rotated file expected")
+			_, err = os.Stat(filePath + ".2")
+			mctest.AssertEquals(t, err, nil, "second rotated file expected")
+			_, err = os.Stat(filePath + ".3")
+			mctest.AssertEquals(t, os.IsNotExist(err), true, "max rotated files expected")
+			info, _ := os.Stat(filePath)
+			mctest.AssertEquals(t, info.Size() <= 500, true, "file size within the max size expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
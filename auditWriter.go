@@ -0,0 +1,344 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: asynchronous (queued and batched) audit-log writer
+
+package mccrud
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"github.com/abbeymart/mcresponse"
+	"os"
+	"sync"
+	"time"
+)
+
+// audit-writer queue overflow policies
+const (
+	AuditOverflowBlock = "block" // wait for the queue space
+	AuditOverflowDrop  = "drop"  // drop the entry, reported by the OnError callback
+	AuditOverflowSpill = "spill" // append the entry to the spill (jsonl) file, for ReplaySpill
+)
+
+// audit-writer errors
+var (
+	ErrAuditWriterClosed = errors.New("audit-log writer is closed")
+	ErrAuditQueueFull    = errors.New("audit-log queue is full, entry dropped")
+)
+
+// AuditQueueEntryType is the queued (or spilled) audit-log entry
+type AuditQueueEntryType struct {
+	LogType string              `json:"logType"`
+	UserId  string              `json:"userId"`
+	Options AuditLogOptionsType `json:"options"`
+}
+
+// AuditWriterOptionsType is the audit-writer queue, batch and overflow options
+type AuditWriterOptionsType struct {
+	QueueSize     int                                        // default: 1000
+	BatchSize     int                                        // entries per batch (transaction), default: 100
+	FlushInterval time.Duration                              // partial batch write interval, default: 1 second
+	Overflow      string                                     // AuditOverflowBlock (default), AuditOverflowDrop or AuditOverflowSpill
+	SpillFile     string                                     // default: audit-spill.jsonl
+	OnError       func(entry AuditQueueEntryType, err error) // write, drop and spill failures
+	Sink          AuditLogger                                // entries writer, if specified (e.g. a file sink), default: the Logger batch transactions
+}
+
+// AsyncAuditWriter writes the queued audit-log entries by batch, with the background worker
+type AsyncAuditWriter struct {
+	AuditWriterOptionsType
+	Logger      LogParamX
+	queue       chan AuditQueueEntryType
+	closed      bool
+	mutex       sync.RWMutex
+	spillMutex  sync.Mutex
+	replayMutex sync.Mutex
+	waitGroup   sync.WaitGroup
+}
+
+// NewAsyncAuditWriter constructor returns a new AsyncAuditWriter instance, and starts the background worker
+func NewAsyncAuditWriter(logger LogParamX, options AuditWriterOptionsType) *AsyncAuditWriter {
+	writer := &AsyncAuditWriter{
+		AuditWriterOptionsType: options,
+		Logger:                 logger,
+	}
+	// the worker logger writes synchronously
+	writer.Logger.Writer = nil
+	// default values
+	if writer.QueueSize <= 0 {
+		writer.QueueSize = 1000
+	}
+	if writer.BatchSize <= 0 {
+		writer.BatchSize = 100
+	}
+	if writer.FlushInterval <= 0 {
+		writer.FlushInterval = time.Second
+	}
+	if writer.Overflow != AuditOverflowDrop && writer.Overflow != AuditOverflowSpill {
+		writer.Overflow = AuditOverflowBlock
+	}
+	if writer.SpillFile == "" {
+		writer.SpillFile = "audit-spill.jsonl"
+	}
+	writer.queue = make(chan AuditQueueEntryType, writer.QueueSize)
+	writer.waitGroup.Add(1)
+	go writer.run()
+	return writer
+}
+
+// reportError reports the entry write failure, by the OnError callback
+func (writer *AsyncAuditWriter) reportError(entry AuditQueueEntryType, err error) {
+	if writer.OnError != nil {
+		writer.OnError(entry, err)
+	}
+}
+
+// copyAuditOptions returns the audit-log options of the json-encoded (copied) payloads, as the queued entry must not
+// reference the caller records, changed after the write
+func copyAuditOptions(options AuditLogOptionsType) (AuditLogOptionsType, error) {
+	copyPayload := func(payload interface{}) (interface{}, error) {
+		if payload == nil {
+			return nil, nil
+		}
+		jsonVal, err := json.Marshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(jsonVal), nil
+	}
+	var err error
+	if options.LogRecords, err = copyPayload(options.LogRecords); err != nil {
+		return options, err
+	}
+	if options.NewLogRecords, err = copyPayload(options.NewLogRecords); err != nil {
+		return options, err
+	}
+	if options.QueryParams != nil {
+		jsonVal, err := json.Marshal(options.QueryParams)
+		if err != nil {
+			return options, err
+		}
+		options.QueryParams = QueryParamType{}
+		if err = json.Unmarshal(jsonVal, &options.QueryParams); err != nil {
+			return options, err
+		}
+	}
+	if options.LogDiff != nil {
+		jsonVal, err := json.Marshal(options.LogDiff)
+		if err != nil {
+			return options, err
+		}
+		options.LogDiff = nil
+		if err = json.Unmarshal(jsonVal, &options.LogDiff); err != nil {
+			return options, err
+		}
+	}
+	if options.RecordIds != nil {
+		options.RecordIds = append([]string{}, options.RecordIds...)
+	}
+	return options, nil
+}
+
+// Write method queues the audit-log entry, of the copied payloads. The full queue entry is handled by the overflow
+// policy.
+func (writer *AsyncAuditWriter) Write(logType, userId string, options AuditLogOptionsType) error {
+	// log time of the action, not of the write
+	if options.LogAt.IsZero() {
+		options.LogAt = time.Now()
+	}
+	options, err := copyAuditOptions(options)
+	if err != nil {
+		return err
+	}
+	entry := AuditQueueEntryType{
+		LogType: logType,
+		UserId:  userId,
+		Options: options,
+	}
+	writer.mutex.RLock()
+	defer writer.mutex.RUnlock()
+	if writer.closed {
+		return ErrAuditWriterClosed
+	}
+	if writer.Overflow == AuditOverflowBlock {
+		writer.queue <- entry
+		return nil
+	}
+	select {
+	case writer.queue <- entry:
+		return nil
+	default:
+	}
+	if writer.Overflow == AuditOverflowSpill {
+		if err := writer.spill(entry); err != nil {
+			writer.reportError(entry, err)
+			return err
+		}
+		return nil
+	}
+	writer.reportError(entry, ErrAuditQueueFull)
+	return ErrAuditQueueFull
+}
+
+// Close method stops the queue, and waits for the queued entries to be written. Call on shutdown.
+func (writer *AsyncAuditWriter) Close() {
+	writer.mutex.Lock()
+	if writer.closed {
+		writer.mutex.Unlock()
+		return
+	}
+	writer.closed = true
+	close(writer.queue)
+	writer.mutex.Unlock()
+	writer.waitGroup.Wait()
+}
+
+// run writes the queued entries, by batch size or flush interval, until the queue is closed
+func (writer *AsyncAuditWriter) run() {
+	defer writer.waitGroup.Done()
+	ticker := time.NewTicker(writer.FlushInterval)
+	defer ticker.Stop()
+	var batch []AuditQueueEntryType
+	for {
+		select {
+		case entry, ok := <-writer.queue:
+			if !ok {
+				writer.writeBatch(batch)
+				return
+			}
+			batch = append(batch, entry)
+			if len(batch) >= writer.BatchSize {
+				writer.writeBatch(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			writer.writeBatch(batch)
+			batch = nil
+		}
+	}
+}
+
+// writeBatch writes the batch entries in a transaction, or by the Sink. The failed batch entries are re-written
+// individually, to report the failed entries. The hash-chained entries are written individually, by chain transaction.
+func (writer *AsyncAuditWriter) writeBatch(entries []AuditQueueEntryType) {
+	if len(entries) < 1 {
+		return
+	}
+	if len(entries) > 1 && writer.Sink == nil && !writer.Logger.HashChain {
+		if err := writer.writeTx(entries); err == nil {
+			return
+		}
+	}
+	for _, entry := range entries {
+		if _, err := writer.writeEntry(entry); err != nil {
+			writer.reportError(entry, err)
+		}
+	}
+}
+
+// writeEntry writes the entry, by the Sink or the Logger
+func (writer *AsyncAuditWriter) writeEntry(entry AuditQueueEntryType) (mcresponse.ResponseMessage, error) {
+	if writer.Sink != nil {
+		return writer.Sink.AuditLog(entry.LogType, entry.UserId, entry.Options)
+	}
+	return writer.Logger.AuditLog(entry.LogType, entry.UserId, entry.Options)
+}
+
+// writeTx writes the batch entries in a transaction
+func (writer *AsyncAuditWriter) writeTx(entries []AuditQueueEntryType) error {
+	tx, err := writer.Logger.AuditDb.Beginx()
+	if err != nil {
+		return err
+	}
+	txLogger := writer.Logger
+	txLogger.execer = tx
+	for _, entry := range entries {
+		if _, err = txLogger.AuditLog(entry.LogType, entry.UserId, entry.Options); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// spill appends the entry to the spill file
+func (writer *AsyncAuditWriter) spill(entry AuditQueueEntryType) error {
+	jsonVal, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	writer.spillMutex.Lock()
+	defer writer.spillMutex.Unlock()
+	file, err := os.OpenFile(writer.SpillFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(jsonVal, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ReplaySpill method writes (synchronously) the spilled entries, and returns the written entries count. The failed
+// entries are reported and spilled again. The replay file of an interrupted replay (read error or crash) is replayed
+// first, and never overwritten.
+func (writer *AsyncAuditWriter) ReplaySpill() (int, error) {
+	writer.replayMutex.Lock()
+	defer writer.replayMutex.Unlock()
+	replayFile := writer.SpillFile + ".replay"
+	count := 0
+	if _, err := os.Stat(replayFile); err == nil {
+		replayCount, err := writer.replayFile(replayFile)
+		count += replayCount
+		if err != nil {
+			return count, err
+		}
+	} else if !os.IsNotExist(err) {
+		return 0, err
+	}
+	writer.spillMutex.Lock()
+	err := os.Rename(writer.SpillFile, replayFile)
+	writer.spillMutex.Unlock()
+	if os.IsNotExist(err) {
+		return count, nil
+	}
+	if err != nil {
+		return count, err
+	}
+	replayCount, err := writer.replayFile(replayFile)
+	return count + replayCount, err
+}
+
+// replayFile writes the entries of the replay file, and removes the replay file after all the entries are read
+func (writer *AsyncAuditWriter) replayFile(replayFile string) (int, error) {
+	file, err := os.Open(replayFile)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry AuditQueueEntryType
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			writer.reportError(entry, err)
+			continue
+		}
+		if _, err = writer.writeEntry(entry); err != nil {
+			writer.reportError(entry, err)
+			if spillErr := writer.spill(entry); spillErr != nil {
+				writer.reportError(entry, spillErr)
+			}
+			continue
+		}
+		count++
+	}
+	scanErr := scanner.Err()
+	_ = file.Close()
+	if scanErr != nil {
+		return count, scanErr
+	}
+	return count, os.Remove(replayFile)
+}
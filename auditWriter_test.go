@@ -0,0 +1,199 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: asynchronous audit-log writer test cases
+
+package mccrud
+
+import (
+	"encoding/json"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingAuditSink blocks the first entry write, until released
+type blockingAuditSink struct {
+	*MemoryAuditSink
+	started chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+// newBlockingAuditSink returns a new blockingAuditSink instance
+func newBlockingAuditSink() *blockingAuditSink {
+	return &blockingAuditSink{
+		MemoryAuditSink: NewMemoryAuditSink(),
+		started:         make(chan struct{}),
+		release:         make(chan struct{}),
+	}
+}
+
+// AuditLog method blocks the first entry write, and appends the entry
+func (sink *blockingAuditSink) AuditLog(logType, userId string, options AuditLogOptionsType) (mcresponse.ResponseMessage, error) {
+	sink.once.Do(func() {
+		close(sink.started)
+		<-sink.release
+	})
+	return sink.MemoryAuditSink.AuditLog(logType, userId, options)
+}
+
+// testQueueEntry returns the create audit-log options of the record id
+func testQueueEntry(recordId string) AuditLogOptionsType {
+	return AuditLogOptionsType{TableName: "groups", LogRecords: map[string]interface{}{"id": recordId}}
+}
+
+// auditEntryIds returns the record ids of the sink entries
+func auditEntryIds(entries []AuditEntryType) []string {
+	var ids []string
+	for _, entry := range entries {
+		var record map[string]interface{}
+		jsonVal, _ := json.Marshal(entry.LogRecords)
+		_ = json.Unmarshal(jsonVal, &record)
+		ids = append(ids, record["id"].(string))
+	}
+	return ids
+}
+
+func TestAsyncAuditWriter(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should write the queued entries by batch, and flush the partial batch on close:",
+		TestFunc: func() {
+			sink := NewMemoryAuditSink()
+			writer := NewAsyncAuditWriter(LogParamX{}, AuditWriterOptionsType{BatchSize: 2, FlushInterval: time.Hour, Sink: sink})
+			for _, recordId := range []string{"g-1", "g-2", "g-3"} {
+				mctest.AssertEquals(t, writer.Write(CreateLog, "user-1", testQueueEntry(recordId)), nil, "write error should be: nil")
+			}
+			writer.Close()
+			mctest.AssertEquals(t, auditEntryIds(sink.Entries()), []string{"g-1", "g-2", "g-3"}, "written entries, in the write order, expected")
+			mctest.AssertEquals(t, writer.Write(CreateLog, "user-1", testQueueEntry("g-4")), ErrAuditWriterClosed, "closed writer error expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should write the partial batch by the flush interval:",
+		TestFunc: func() {
+			sink := NewMemoryAuditSink()
+			writer := NewAsyncAuditWriter(LogParamX{}, AuditWriterOptionsType{FlushInterval: 10 * time.Millisecond, Sink: sink})
+			defer writer.Close()
+			_ = writer.Write(CreateLog, "user-1", testQueueEntry("g-1"))
+			deadline := time.Now().Add(2 * time.Second)
+			for len(sink.Entries()) < 1 && time.Now().Before(deadline) {
+				time.Sleep(5 * time.Millisecond)
+			}
+			mctest.AssertEquals(t, len(sink.Entries()), 1, "flushed entry expected, before close")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should queue the copied payloads, not the caller records:",
+		TestFunc: func() {
+			sink := NewMemoryAuditSink()
+			writer := NewAsyncAuditWriter(LogParamX{}, AuditWriterOptionsType{FlushInterval: time.Hour, Sink: sink})
+			record := map[string]interface{}{"id": "g-1", "name": "Group"}
+			options := AuditLogOptionsType{TableName: "groups", LogRecords: []map[string]interface{}{record}, QueryParams: QueryParamType{"name": "Group"}}
+			_ = writer.Write(CreateLog, "user-1", options)
+			record["name"] = "Changed"
+			options.QueryParams["name"] = "Changed"
+			writer.Close()
+			entries := sink.Entries()
+			mctest.AssertEquals(t, len(entries), 1, "one entry expected")
+			mctest.AssertEquals(t, entries[0].LogRecords, json.RawMessage(`[{"id":"g-1","name":"Group"}]`), "logged records, as of the write, expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should drop the entries of the full queue, by the drop policy:",
+		TestFunc: func() {
+			sink := newBlockingAuditSink()
+			var dropped []AuditQueueEntryType
+			writer := NewAsyncAuditWriter(LogParamX{}, AuditWriterOptionsType{QueueSize: 1, BatchSize: 1, Overflow: AuditOverflowDrop, Sink: sink,
+				OnError: func(entry AuditQueueEntryType, err error) {
+					dropped = append(dropped, entry)
+				},
+			})
+			_ = writer.Write(CreateLog, "user-1", testQueueEntry("g-1"))
+			<-sink.started
+			mctest.AssertEquals(t, writer.Write(CreateLog, "user-1", testQueueEntry("g-2")), nil, "queued entry expected")
+			mctest.AssertEquals(t, writer.Write(CreateLog, "user-1", testQueueEntry("g-3")), ErrAuditQueueFull, "queue full error expected")
+			mctest.AssertEquals(t, len(dropped), 1, "reported dropped entry expected")
+			close(sink.release)
+			writer.Close()
+			mctest.AssertEquals(t, auditEntryIds(sink.Entries()), []string{"g-1", "g-2"}, "written entries, without the dropped entry, expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should wait for the queue space, by the block policy:",
+		TestFunc: func() {
+			sink := newBlockingAuditSink()
+			writer := NewAsyncAuditWriter(LogParamX{}, AuditWriterOptionsType{QueueSize: 1, BatchSize: 1, Sink: sink})
+			_ = writer.Write(CreateLog, "user-1", testQueueEntry("g-1"))
+			<-sink.started
+			_ = writer.Write(CreateLog, "user-1", testQueueEntry("g-2"))
+			written := make(chan error)
+			go func() {
+				written <- writer.Write(CreateLog, "user-1", testQueueEntry("g-3"))
+			}()
+			select {
+			case <-written:
+				t.Errorf("blocked write expected, of the full queue")
+			case <-time.After(50 * time.Millisecond):
+			}
+			close(sink.release)
+			mctest.AssertEquals(t, <-written, nil, "write error should be: nil, after the queue space")
+			writer.Close()
+			mctest.AssertEquals(t, auditEntryIds(sink.Entries()), []string{"g-1", "g-2", "g-3"}, "all entries written expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should spill the entries of the full queue, and replay the spilled entries:",
+		TestFunc: func() {
+			sink := newBlockingAuditSink()
+			spillFile := filepath.Join(t.TempDir(), "audit-spill.jsonl")
+			writer := NewAsyncAuditWriter(LogParamX{}, AuditWriterOptionsType{QueueSize: 1, BatchSize: 1, Overflow: AuditOverflowSpill, SpillFile: spillFile, Sink: sink})
+			_ = writer.Write(CreateLog, "user-1", testQueueEntry("g-1"))
+			<-sink.started
+			_ = writer.Write(CreateLog, "user-1", testQueueEntry("g-2"))
+			mctest.AssertEquals(t, writer.Write(CreateLog, "user-1", testQueueEntry("g-3")), nil, "spilled entry error should be: nil")
+			close(sink.release)
+			writer.Close()
+			mctest.AssertEquals(t, auditEntryIds(sink.Entries()), []string{"g-1", "g-2"}, "queued entries expected")
+			count, err := writer.ReplaySpill()
+			mctest.AssertEquals(t, err, nil, "replay error should be: nil")
+			mctest.AssertEquals(t, count, 1, "one replayed entry expected")
+			mctest.AssertEquals(t, auditEntryIds(sink.Entries()), []string{"g-1", "g-2", "g-3"}, "replayed entry expected")
+			_, err = os.Stat(spillFile)
+			mctest.AssertEquals(t, os.IsNotExist(err), true, "replayed spill file removed expected")
+			count, err = writer.ReplaySpill()
+			mctest.AssertEquals(t, count, 0, "no spilled entries expected")
+			mctest.AssertEquals(t, err, nil, "replay error should be: nil")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should replay the left replay file first, without overwriting it:",
+		TestFunc: func() {
+			sink := NewMemoryAuditSink()
+			spillFile := filepath.Join(t.TempDir(), "audit-spill.jsonl")
+			writer := NewAsyncAuditWriter(LogParamX{}, AuditWriterOptionsType{Overflow: AuditOverflowSpill, SpillFile: spillFile, Sink: sink})
+			defer writer.Close()
+			// the replay file of an interrupted replay, and the later spilled entry
+			mctest.AssertEquals(t, writer.spill(AuditQueueEntryType{LogType: CreateLog, UserId: "user-1", Options: testQueueEntry("g-1")}), nil, "spill error should be: nil")
+			mctest.AssertEquals(t, os.Rename(spillFile, spillFile+".replay"), nil, "rename error should be: nil")
+			mctest.AssertEquals(t, writer.spill(AuditQueueEntryType{LogType: CreateLog, UserId: "user-1", Options: testQueueEntry("g-2")}), nil, "spill error should be: nil")
+			count, err := writer.ReplaySpill()
+			mctest.AssertEquals(t, err, nil, "replay error should be: nil")
+			mctest.AssertEquals(t, count, 2, "two replayed entries expected")
+			mctest.AssertEquals(t, auditEntryIds(sink.Entries()), []string{"g-1", "g-2"}, "replayed entries, of the replay and spill files, expected")
+			_, err = os.Stat(spillFile + ".replay")
+			mctest.AssertEquals(t, os.IsNotExist(err), true, "replayed replay file removed expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
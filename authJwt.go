@@ -0,0 +1,242 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: offline JWT (HMAC: HS256, HS384, HS512) authentication provider
+
+package mccrud
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"hash"
+	"strings"
+	"time"
+)
+
+// JwtAuthenticator validates the signature, expiry and claims of the (HMAC-signed) user-token,
+// and maps the claims to the AccessInfoType, without a database round trip
+type JwtAuthenticator struct {
+	Secret        []byte
+	Algorithms    []string      // permitted algorithms, default: HS256
+	Issuer        string        // expected iss claim, optional
+	Audience      string        // expected aud claim, optional
+	Leeway        time.Duration // clock-skew allowance, for exp, nbf and iat claims
+	UserIdClaim   string        // default: sub
+	RoleIdClaim   string        // default: roleId
+	RoleIdsClaim  string        // default: roleIds
+	IsAdminClaim  string        // default: isAdmin
+	IsActiveClaim string        // default: isActive, users are active if the claim is not specified
+}
+
+// NewJwtAuthenticator constructor returns a new JwtAuthenticator instance, with the default claim-names
+func NewJwtAuthenticator(secret []byte, options JwtAuthenticator) *JwtAuthenticator {
+	auth := options
+	auth.Secret = secret
+	if len(auth.Algorithms) < 1 {
+		auth.Algorithms = []string{"HS256"}
+	}
+	if auth.UserIdClaim == "" {
+		auth.UserIdClaim = "sub"
+	}
+	if auth.RoleIdClaim == "" {
+		auth.RoleIdClaim = "roleId"
+	}
+	if auth.RoleIdsClaim == "" {
+		auth.RoleIdsClaim = "roleIds"
+	}
+	if auth.IsAdminClaim == "" {
+		auth.IsAdminClaim = "isAdmin"
+	}
+	if auth.IsActiveClaim == "" {
+		auth.IsActiveClaim = "isActive"
+	}
+	return &auth
+}
+
+// jwtHashFunc returns the hash-function for the HMAC algorithm
+func jwtHashFunc(alg string) (func() hash.Hash, error) {
+	switch alg {
+	case "HS256":
+		return sha256.New, nil
+	case "HS384":
+		return sha512.New384, nil
+	case "HS512":
+		return sha512.New, nil
+	default:
+		return nil, errors.New(fmt.Sprintf("unsupported jwt algorithm: %v", alg))
+	}
+}
+
+// SignJwtToken function returns the HMAC-signed (HS256, HS384 or HS512) JWT of the claims
+func SignJwtToken(claims map[string]interface{}, secret []byte, alg string) (string, error) {
+	hashFunc, err := jwtHashFunc(alg)
+	if err != nil {
+		return "", err
+	}
+	header, _ := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(hashFunc, secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// ParseClaims method validates the token signature, and returns the decoded claims
+func (auth *JwtAuthenticator) ParseClaims(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("invalid token format")
+	}
+	headerJson, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid token header: %v", err.Error()))
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err = json.Unmarshal(headerJson, &header); err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid token header: %v", err.Error()))
+	}
+	if !ArrayStringContains(auth.Algorithms, header.Alg) {
+		return nil, errors.New(fmt.Sprintf("token algorithm not permitted: %v", header.Alg))
+	}
+	hashFunc, err := jwtHashFunc(header.Alg)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid token signature: %v", err.Error()))
+	}
+	mac := hmac.New(hashFunc, auth.Secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errors.New("invalid token signature")
+	}
+	payloadJson, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid token payload: %v", err.Error()))
+	}
+	claims := map[string]interface{}{}
+	if err = json.Unmarshal(payloadJson, &claims); err != nil {
+		return nil, errors.New(fmt.Sprintf("invalid token payload: %v", err.Error()))
+	}
+	return claims, nil
+}
+
+// Authenticate method validates the user-token (JWT) and returns the AccessInfoType from the token claims
+func (auth *JwtAuthenticator) Authenticate(userInfo UserInfoType) mcresponse.ResponseMessage {
+	if len(auth.Secret) < 1 {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: jwt secret is required",
+			Value:   nil,
+		})
+	}
+	claims, err := auth.ParseClaims(userInfo.Token)
+	if err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// registered claims: exp (required), nbf, iat, iss and aud
+	now := time.Now()
+	exp, expOk := claims["exp"].(float64)
+	if !expOk {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: token expiry (exp) claim is required",
+			Value:   nil,
+		})
+	}
+	if now.After(time.Unix(int64(exp), 0).Add(auth.Leeway)) {
+		return mcresponse.GetResMessage("tokenExpired", mcresponse.ResponseMessageOptions{
+			Message: "Access expired: please login to continue",
+			Value:   nil,
+		})
+	}
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(auth.Leeway).Before(time.Unix(int64(nbf), 0)) {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: token not yet valid (nbf)",
+			Value:   nil,
+		})
+	}
+	if iat, ok := claims["iat"].(float64); ok && now.Add(auth.Leeway).Before(time.Unix(int64(iat), 0)) {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: token issued-at (iat) is in the future",
+			Value:   nil,
+		})
+	}
+	if auth.Issuer != "" && claims["iss"] != auth.Issuer {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: invalid token issuer (iss)",
+			Value:   nil,
+		})
+	}
+	if auth.Audience != "" && !ArrayStringContains(jwtClaimStrings(claims["aud"]), auth.Audience) {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: invalid token audience (aud)",
+			Value:   nil,
+		})
+	}
+	// map claims to the user access-information
+	userId, _ := claims[auth.UserIdClaim].(string)
+	if userId == "" {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: user-id (%v) claim is required", auth.UserIdClaim),
+			Value:   nil,
+		})
+	}
+	if userInfo.UserId != "" && userInfo.UserId != userId {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: user-id does not match the token subject",
+			Value:   nil,
+		})
+	}
+	roleId, _ := claims[auth.RoleIdClaim].(string)
+	isAdmin, _ := claims[auth.IsAdminClaim].(bool)
+	isActive := true
+	if activeVal, ok := claims[auth.IsActiveClaim].(bool); ok {
+		isActive = activeVal
+	}
+	if !isActive {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: user information not found or is inactive",
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Action authorised / permitted.",
+		Value: AccessInfoType{
+			UserId:   userId,
+			RoleId:   roleId,
+			RoleIds:  jwtClaimStrings(claims[auth.RoleIdsClaim]),
+			IsAdmin:  isAdmin,
+			IsActive: isActive,
+			Expire:   int64(exp) * 1000,
+		},
+	})
+}
+
+// jwtClaimStrings returns the string or array-of-strings claim-value as []string
+func jwtClaimStrings(claim interface{}) []string {
+	var values []string
+	switch val := claim.(type) {
+	case string:
+		values = append(values, val)
+	case []interface{}:
+		for _, item := range val {
+			if itemStr, ok := item.(string); ok {
+				values = append(values, itemStr)
+			}
+		}
+	}
+	return values
+}
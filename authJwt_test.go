@@ -0,0 +1,72 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: jwt authentication provider test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestJwtAuthenticator(t *testing.T) {
+	secret := []byte("test-secret")
+	auth := NewJwtAuthenticator(secret, JwtAuthenticator{Issuer: "mconnect", Audience: "mccrud"})
+	validClaims := map[string]interface{}{
+		"sub":     "c85509ac-7373-464d-b667-425bb59b5738",
+		"roleIds": []string{"admin-role", "staff-role"},
+		"isAdmin": true,
+		"iss":     "mconnect",
+		"aud":     []string{"mccrud", "other"},
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should validate token and map claims to access-info:",
+		TestFunc: func() {
+			token, _ := SignJwtToken(validClaims, secret, "HS256")
+			res := auth.Authenticate(UserInfoType{Token: token})
+			mctest.AssertEquals(t, res.Code, "success", "response-code should be: success")
+			accessInfo, ok := res.Value.(AccessInfoType)
+			mctest.AssertEquals(t, ok, true, "response-value should be AccessInfoType")
+			mctest.AssertEquals(t, accessInfo.UserId, "c85509ac-7373-464d-b667-425bb59b5738", "userId should match sub claim")
+			mctest.AssertEquals(t, accessInfo.RoleIds, []string{"admin-role", "staff-role"}, "roleIds should match roleIds claim")
+			mctest.AssertEquals(t, accessInfo.IsAdmin, true, "isAdmin should be true")
+			mctest.AssertEquals(t, accessInfo.IsActive, true, "isActive should be true")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should reject token with invalid signature or algorithm:",
+		TestFunc: func() {
+			token, _ := SignJwtToken(validClaims, []byte("wrong-secret"), "HS256")
+			res := auth.Authenticate(UserInfoType{Token: token})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "response-code should be: unAuthorized")
+			token, _ = SignJwtToken(validClaims, secret, "HS512")
+			res = auth.Authenticate(UserInfoType{Token: token})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "HS512 not permitted, response-code should be: unAuthorized")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should reject expired token and invalid claims:",
+		TestFunc: func() {
+			claims := map[string]interface{}{"sub": "user-1", "iss": "mconnect", "aud": "mccrud", "exp": time.Now().Add(-time.Minute).Unix()}
+			token, _ := SignJwtToken(claims, secret, "HS256")
+			res := auth.Authenticate(UserInfoType{Token: token})
+			mctest.AssertEquals(t, res.Code, "tokenExpired", "response-code should be: tokenExpired")
+			claims["exp"] = time.Now().Add(time.Hour).Unix()
+			claims["aud"] = "other"
+			token, _ = SignJwtToken(claims, secret, "HS256")
+			res = auth.Authenticate(UserInfoType{Token: token})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "invalid audience, response-code should be: unAuthorized")
+			claims["aud"] = "mccrud"
+			token, _ = SignJwtToken(claims, secret, "HS256")
+			res = auth.Authenticate(UserInfoType{UserId: "user-2", Token: token})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "mismatched userId, response-code should be: unAuthorized")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,138 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: pluggable authentication providers, for CheckUserAccess and CheckLoginStatus
+
+package mccrud
+
+import (
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+// Authenticator validates the user-information/token.
+// The success response value must be the AccessInfoType of the authenticated user.
+type Authenticator interface {
+	Authenticate(userInfo UserInfoType) mcresponse.ResponseMessage
+}
+
+// DbTokenAuthenticator validates the user-token from the access-table, and the user-status from the user-table
+type DbTokenAuthenticator struct {
+	AccessDb    *sqlx.DB
+	AccessTable string
+	UserTable   string
+}
+
+// NewDbTokenAuthenticator constructor returns a new DbTokenAuthenticator instance
+func NewDbTokenAuthenticator(accessDb *sqlx.DB, accessTable string, userTable string) *DbTokenAuthenticator {
+	auth := &DbTokenAuthenticator{
+		AccessDb:    accessDb,
+		AccessTable: accessTable,
+		UserTable:   userTable,
+	}
+	if auth.AccessTable == "" {
+		auth.AccessTable = "accesses"
+	}
+	if auth.UserTable == "" {
+		auth.UserTable = "users"
+	}
+	return auth
+}
+
+// Authenticate method validates the user-token (expire in milliseconds) and the active user-information
+func (auth *DbTokenAuthenticator) Authenticate(userInfo UserInfoType) mcresponse.ResponseMessage {
+	// validate current user active status: by token (API) and user/loggedIn-status
+	// get the accessKey information for the user
+	accessScript := fmt.Sprintf("SELECT expire from %v WHERE user_id=$1 AND token=$2 AND login_name=$3", auth.AccessTable)
+	rowAccess := auth.AccessDb.QueryRow(accessScript, userInfo.UserId, userInfo.Token, userInfo.LoginName)
+	// check login-status/expiration
+	var accessExpire int64
+	if err := rowAccess.Scan(&accessExpire); err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: please ensure that you are logged-in: %v", err.Error()),
+			Value:   nil,
+		})
+	} else {
+		if (time.Now().Unix() * 1000) > accessExpire {
+			return mcresponse.GetResMessage("tokenExpired", mcresponse.ResponseMessageOptions{
+				Message: "Access expired: please login to continue",
+				Value:   nil,
+			})
+		}
+	}
+	// check the current-user status/info
+	userRes := GetUserAccessInfo(auth.AccessDb, auth.UserTable, userInfo.UserId)
+	if accessInfo, ok := userRes.Value.(AccessInfoType); ok {
+		accessInfo.Expire = accessExpire
+		userRes.Value = accessInfo
+	}
+	return userRes
+}
+
+// GetUserAccessInfo function returns the access information (AccessInfoType) of the active user
+func GetUserAccessInfo(accessDb *sqlx.DB, userTable string, userId string) mcresponse.ResponseMessage {
+	var (
+		uId      string
+		roleIds  interface{} // IDs type
+		isAdmin  bool
+		isActive bool
+		profile  interface{} // Profile type
+	)
+	userScript := fmt.Sprintf("SELECT id, role_ids, is_admin, profile, is_active from %v WHERE id=$1 AND is_active=$2", userTable)
+	rowUser := accessDb.QueryRow(userScript, userId, true)
+	if err := rowUser.Scan(&uId, &roleIds, &isAdmin, &profile, &isActive); err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: user information not found or is inactive: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// transform, roleIds and profile (base64String-values) to roleIdsVal and profileVal
+	roleIdsModel := IDs{}
+	profileModel := Profile{}
+
+	rIdsVal, rErr := ConvertJsonBase64StringToTypeValue(roleIds, &roleIdsModel)
+	if rErr != nil {
+		rIdsVal = roleIdsModel
+		//return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+		//	Message: fmt.Sprintf("Error parsing roleIds-json-value: %v", rErr.Error()),
+		//	Value:   nil,
+		//})
+	}
+	pVal, pErr := ConvertJsonBase64StringToTypeValue(profile, &profileModel)
+	if pErr != nil {
+		pVal = profileModel
+		//return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+		//	Message: fmt.Sprintf("Error parsing user-profile-json-value: %v", pErr.Error()),
+		//	Value:   nil,
+		//})
+	}
+
+	roleIdsVal, rOk := rIdsVal.(IDs)
+	if !rOk {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error asserting-type of the parsed roleIds-json-value"),
+			Value:   nil,
+		})
+	}
+	profileVal, pOk := pVal.(Profile)
+	if !pOk {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error asserting-type of the parsed user-profile-json-value"),
+			Value:   nil,
+		})
+	}
+
+	// if all went well
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Action authorised / permitted.",
+		Value: AccessInfoType{
+			UserId:   uId,
+			RoleId:   profileVal.RoleId,
+			RoleIds:  roleIdsVal,
+			IsAdmin:  isAdmin,
+			IsActive: isActive,
+			Profile:  profileVal,
+		},
+	})
+}
@@ -0,0 +1,197 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: crud query-results cache: the Cache (backend) interface and stats, the no-op cache, and the crud
+// cache keys (access scopes) and tags. The cached queries are tagged by the table (and CacheTags related tables), and
+// invalidated by the tagged tables writes.
+
+package mccrud
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// Cache is the query-results cache backend, e.g. LRUCache (in-process), RedisCache (shared) or NoopCache. The
+// in-process caches return the cached value; the shared caches return the json-encoded value ([]byte).
+type Cache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, expire time.Duration, tags []string) error
+	Delete(keys ...string) error
+	InvalidateTags(tags ...string) error
+	Stats() CacheStatsType
+}
+
+// CacheStatsType is the cache hits, misses, sets, evictions (size-bounded removals) and invalidations counts
+type CacheStatsType struct {
+	Hits          int64 `json:"hits"`
+	Misses        int64 `json:"misses"`
+	Sets          int64 `json:"sets"`
+	Evictions     int64 `json:"evictions"`
+	Invalidations int64 `json:"invalidations"` // entries removed by key or tag
+	Entries       int64 `json:"entries"`       // current entries, of the in-process caches
+}
+
+// cacheStats is the concurrent cache stats counters
+type cacheStats struct {
+	hits          int64
+	misses        int64
+	sets          int64
+	evictions     int64
+	invalidations int64
+}
+
+// hit records the cache lookup hit or miss
+func (stats *cacheStats) hit(ok bool) {
+	if ok {
+		atomic.AddInt64(&stats.hits, 1)
+	} else {
+		atomic.AddInt64(&stats.misses, 1)
+	}
+}
+
+// value returns the stats counters values
+func (stats *cacheStats) value() CacheStatsType {
+	return CacheStatsType{
+		Hits:          atomic.LoadInt64(&stats.hits),
+		Misses:        atomic.LoadInt64(&stats.misses),
+		Sets:          atomic.LoadInt64(&stats.sets),
+		Evictions:     atomic.LoadInt64(&stats.evictions),
+		Invalidations: atomic.LoadInt64(&stats.invalidations),
+	}
+}
+
+// NoopCache does not cache, e.g. to disable the query-results cache. The lookups are counted as misses.
+type NoopCache struct {
+	stats cacheStats
+}
+
+// NewNoopCache constructor returns a new NoopCache instance
+func NewNoopCache() *NoopCache {
+	return &NoopCache{}
+}
+
+// Get method returns the cache miss
+func (cache *NoopCache) Get(key string) (interface{}, bool) {
+	cache.stats.hit(false)
+	return nil, false
+}
+
+// Set method ignores the value
+func (cache *NoopCache) Set(key string, value interface{}, expire time.Duration, tags []string) error {
+	return nil
+}
+
+// Delete method ignores the keys
+func (cache *NoopCache) Delete(keys ...string) error {
+	return nil
+}
+
+// InvalidateTags method ignores the tags
+func (cache *NoopCache) InvalidateTags(tags ...string) error {
+	return nil
+}
+
+// Stats method returns the cache stats
+func (cache *NoopCache) Stats() CacheStatsType {
+	return cache.stats.value()
+}
+
+// DefaultCacheEntries is the DefaultCache size
+const DefaultCacheEntries = 10000
+
+// DefaultCache is the query-results cache of the crud instances without a Cache option: the process-wide LRU cache
+var DefaultCache Cache = NewLRUCache(DefaultCacheEntries)
+
+// cache returns the crud query-results cache, default: DefaultCache
+func (crud *Crud) cache() Cache {
+	if crud.Cache == nil {
+		return DefaultCache
+	}
+	return crud.Cache
+}
+
+// CacheAccessScope function returns the cache scope of the access context: the tenant, user, roles and admin status
+func CacheAccessScope(appId string, userId string, loginName string, roleIds []string, isAdmin bool) string {
+	roles := append([]string{}, roleIds...)
+	sort.Strings(roles)
+	scopeVal, _ := json.Marshal([]interface{}{appId, userId, loginName, roles, isAdmin})
+	scopeHash := sha256.Sum256(scopeVal)
+	return "access-" + hex.EncodeToString(scopeHash[:16])
+}
+
+// cacheScope returns the cache scope of the crud query results: the access context (CacheAccessScope), or the tenant
+// (shared), for the CacheSharedTables. The shared tables are scoped by the access context, if the user-specific access
+// rules (field access, shares or policies) are enabled.
+func (crud *Crud) cacheScope() string {
+	if ArrayStringContains(crud.CacheSharedTables, crud.TableName) && !crud.CheckFieldAccess && !crud.ShareAccess && !crud.PolicyCheck {
+		return "shared-" + crud.AppParams.AppId
+	}
+	userId := crud.AccessInfo.UserId
+	if userId == "" {
+		userId = crud.UserInfo.UserId
+	}
+	roleIds := crud.AccessInfo.RoleIds
+	if len(roleIds) < 1 && crud.AccessInfo.RoleId != "" {
+		roleIds = []string{crud.AccessInfo.RoleId}
+	}
+	return CacheAccessScope(crud.AppParams.AppId, userId, crud.UserInfo.LoginName, roleIds, crud.AccessInfo.IsAdmin)
+}
+
+// cacheEntryKey returns the cache key of the crud table, access scope and query
+func (crud *Crud) cacheEntryKey() string {
+	return fmt.Sprintf("%v:%v:%v", crud.TableName, crud.cacheScope(), crud.CacheKey)
+}
+
+// getCache returns the cached query result of the crud table and query (CacheKey)
+func (crud *Crud) getCache() (GetResultType, bool) {
+	cacheVal, ok := crud.cache().Get(crud.cacheEntryKey())
+	if !ok {
+		return GetResultType{}, false
+	}
+	var val GetResultType
+	switch v := cacheVal.(type) {
+	case GetResultType:
+		val = v
+	case []byte:
+		if err := json.Unmarshal(v, &val); err != nil {
+			return GetResultType{}, false
+		}
+	default:
+		return GetResultType{}, false
+	}
+	if len(val.Records) < 1 {
+		return GetResultType{}, false
+	}
+	return val, true
+}
+
+// setCache caches the query result of the crud table and query (CacheKey), tagged by the table and the CacheTags
+func (crud *Crud) setCache(getResult GetResultType) {
+	tags := []string{crud.TableName}
+	for _, tag := range crud.CacheTags {
+		if !ArrayStringContains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	_ = crud.cache().Set(crud.cacheEntryKey(), getResult, time.Duration(crud.CacheExpire)*time.Second, tags)
+}
+
+// InvalidateTableCache function removes all the cache (nil: DefaultCache) queries of the table, and the queries
+// tagged by the table (CacheTags), e.g. on the table writes outside the crud methods
+func InvalidateTableCache(cache Cache, tableName string) {
+	if cache == nil {
+		cache = DefaultCache
+	}
+	_ = cache.InvalidateTags(tableName)
+}
+
+// invalidateCache removes the cached queries of the crud table, and the queries tagged by the crud table, on the crud
+// writes (create, update and delete)
+func (crud *Crud) invalidateCache() {
+	_ = crud.cache().InvalidateTags(crud.TableName)
+}
@@ -0,0 +1,148 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: size-bounded (least recently used) in-process cache, with expiry and tags
+
+package mccrud
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// lruEntry is the LRU cache entry
+type lruEntry struct {
+	key    string
+	value  interface{}
+	expire time.Time // no expiry, if zero
+	tags   []string
+}
+
+// LRUCache is the in-process cache of up to MaxEntries entries, evicting the least recently used entries
+type LRUCache struct {
+	MaxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // most recently used first
+	tagKeys    map[string]map[string]struct{}
+	stats      cacheStats
+	mutex      sync.Mutex
+}
+
+// NewLRUCache constructor returns a new LRUCache instance, of the max entries (default: DefaultCacheEntries)
+func NewLRUCache(maxEntries int) *LRUCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultCacheEntries
+	}
+	return &LRUCache{
+		MaxEntries: maxEntries,
+		entries:    map[string]*list.Element{},
+		order:      list.New(),
+		tagKeys:    map[string]map[string]struct{}{},
+	}
+}
+
+// remove removes the entry element, and its tags references
+func (cache *LRUCache) remove(element *list.Element) {
+	entry := element.Value.(*lruEntry)
+	cache.order.Remove(element)
+	delete(cache.entries, entry.key)
+	for _, tag := range entry.tags {
+		if keys, ok := cache.tagKeys[tag]; ok {
+			delete(keys, entry.key)
+			if len(keys) < 1 {
+				delete(cache.tagKeys, tag)
+			}
+		}
+	}
+}
+
+// Get method returns the cached (non-expired) value of the key
+func (cache *LRUCache) Get(key string) (interface{}, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	element, ok := cache.entries[key]
+	if ok {
+		entry := element.Value.(*lruEntry)
+		if !entry.expire.IsZero() && time.Now().After(entry.expire) {
+			cache.remove(element)
+			ok = false
+		} else {
+			cache.order.MoveToFront(element)
+		}
+	}
+	cache.stats.hit(ok)
+	if !ok {
+		return nil, false
+	}
+	return element.Value.(*lruEntry).value, true
+}
+
+// Set method caches the value of the key, tags and expire duration (no expiry, if zero), and evicts the least
+// recently used entries, beyond the max entries
+func (cache *LRUCache) Set(key string, value interface{}, expire time.Duration, tags []string) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.entries[key]; ok {
+		cache.remove(element)
+	}
+	entry := &lruEntry{
+		key:   key,
+		value: value,
+		tags:  tags,
+	}
+	if expire > 0 {
+		entry.expire = time.Now().Add(expire)
+	}
+	cache.entries[key] = cache.order.PushFront(entry)
+	for _, tag := range tags {
+		if cache.tagKeys[tag] == nil {
+			cache.tagKeys[tag] = map[string]struct{}{}
+		}
+		cache.tagKeys[tag][key] = struct{}{}
+	}
+	atomic.AddInt64(&cache.stats.sets, 1)
+	for cache.order.Len() > cache.MaxEntries {
+		cache.remove(cache.order.Back())
+		atomic.AddInt64(&cache.stats.evictions, 1)
+	}
+	return nil
+}
+
+// Delete method removes the entries of the keys
+func (cache *LRUCache) Delete(keys ...string) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	for _, key := range keys {
+		if element, ok := cache.entries[key]; ok {
+			cache.remove(element)
+			atomic.AddInt64(&cache.stats.invalidations, 1)
+		}
+	}
+	return nil
+}
+
+// InvalidateTags method removes the entries of the tags
+func (cache *LRUCache) InvalidateTags(tags ...string) error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	for _, tag := range tags {
+		for key := range cache.tagKeys[tag] {
+			if element, ok := cache.entries[key]; ok {
+				cache.remove(element)
+				atomic.AddInt64(&cache.stats.invalidations, 1)
+			}
+		}
+		delete(cache.tagKeys, tag)
+	}
+	return nil
+}
+
+// Stats method returns the cache stats, and the current entries count
+func (cache *LRUCache) Stats() CacheStatsType {
+	stats := cache.stats.value()
+	cache.mutex.Lock()
+	stats.Entries = int64(cache.order.Len())
+	cache.mutex.Unlock()
+	return stats
+}
@@ -0,0 +1,326 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: shared (replicas) cache of the Redis-protocol (RESP) server, with the json-encoded values and the
+// tag sets
+
+package mccrud
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// RedisError is the Redis server error reply
+type RedisError string
+
+// Error method implements the error interface
+func (err RedisError) Error() string {
+	return string(err)
+}
+
+// redisConn is the Redis server connection
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// RedisCache is the shared cache of the Redis (or Redis-protocol compatible) server. The values are json-encoded,
+// and the tags are stored as the sets of the tagged keys.
+type RedisCache struct {
+	Address  string        // host:port, default: localhost:6379
+	Password string        // AUTH password, if specified
+	Db       int           // SELECT db, if specified
+	Prefix   string        // keys prefix, default: mccrud:
+	PoolSize int           // idle connections, default: 4
+	Timeout  time.Duration // dial and command timeout, default: 2 seconds
+	pool     chan *redisConn
+	stats    cacheStats
+}
+
+// NewRedisCache constructor returns a new RedisCache instance, of the server address and options
+func NewRedisCache(address string, options RedisCache) *RedisCache {
+	cache := &RedisCache{
+		Address:  address,
+		Password: options.Password,
+		Db:       options.Db,
+		Prefix:   options.Prefix,
+		PoolSize: options.PoolSize,
+		Timeout:  options.Timeout,
+	}
+	// default values
+	if cache.Address == "" {
+		cache.Address = "localhost:6379"
+	}
+	if cache.Prefix == "" {
+		cache.Prefix = "mccrud:"
+	}
+	if cache.PoolSize <= 0 {
+		cache.PoolSize = 4
+	}
+	if cache.Timeout <= 0 {
+		cache.Timeout = 2 * time.Second
+	}
+	cache.pool = make(chan *redisConn, cache.PoolSize)
+	return cache
+}
+
+// connect returns the idle or a new (authenticated) server connection
+func (cache *RedisCache) connect() (*redisConn, error) {
+	select {
+	case conn := <-cache.pool:
+		return conn, nil
+	default:
+	}
+	netConn, err := net.DialTimeout("tcp", cache.Address, cache.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{conn: netConn, reader: bufio.NewReader(netConn)}
+	if cache.Password != "" {
+		if _, err = cache.command(conn, "AUTH", cache.Password); err != nil {
+			_ = netConn.Close()
+			return nil, err
+		}
+	}
+	if cache.Db > 0 {
+		if _, err = cache.command(conn, "SELECT", strconv.Itoa(cache.Db)); err != nil {
+			_ = netConn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// release returns the connection to the idle pool, or closes the connection, if the pool is full
+func (cache *RedisCache) release(conn *redisConn) {
+	select {
+	case cache.pool <- conn:
+	default:
+		_ = conn.conn.Close()
+	}
+}
+
+// command sends the command, and returns the reply, of the connection
+func (cache *RedisCache) command(conn *redisConn, args ...string) (interface{}, error) {
+	if err := conn.conn.SetDeadline(time.Now().Add(cache.Timeout)); err != nil {
+		return nil, err
+	}
+	request := fmt.Sprintf("*%v\r\n", len(args))
+	for _, arg := range args {
+		request += fmt.Sprintf("$%v\r\n%v\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(conn.conn, request); err != nil {
+		return nil, err
+	}
+	return readRedisReply(conn.reader)
+}
+
+// Do method performs the command, and returns the reply: string, int64, []byte (nil bulk: nil), []interface{} or
+// RedisError (as the error). The connection is closed on the network and protocol errors.
+func (cache *RedisCache) Do(args ...string) (interface{}, error) {
+	conn, err := cache.connect()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := cache.command(conn, args...)
+	if _, ok := err.(RedisError); err != nil && !ok {
+		_ = conn.conn.Close()
+		return nil, err
+	}
+	cache.release(conn)
+	return reply, err
+}
+
+// readRedisLine returns the reply line, without the CRLF
+func readRedisLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", errors.New("invalid redis reply line")
+	}
+	return line[:len(line)-2], nil
+}
+
+// readRedisReply returns the RESP reply
+func readRedisReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := readRedisLine(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 1 {
+		return nil, errors.New("empty redis reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, RedisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		bulk := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, bulk); err != nil {
+			return nil, err
+		}
+		return bulk[:size], nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			if items[i], err = readRedisReply(reader); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("invalid redis reply type: %q", line[0])
+}
+
+// tagKey returns the tag set key
+func (cache *RedisCache) tagKey(tag string) string {
+	return cache.Prefix + "tag:" + tag
+}
+
+// Get method returns the json-encoded value ([]byte) of the key. The server errors are cache misses.
+func (cache *RedisCache) Get(key string) (interface{}, bool) {
+	reply, err := cache.Do("GET", cache.Prefix+key)
+	value, ok := reply.([]byte)
+	ok = err == nil && ok
+	cache.stats.hit(ok)
+	if !ok {
+		return nil, false
+	}
+	return value, true
+}
+
+// redisSetScript sets the value (KEYS[1], ARGV[1]) of the expire ms (ARGV[2], no expiry: 0), and adds the key to the
+// tag sets (KEYS[2:]), atomically. The tag sets expiry is only extended, to the longest-lived tagged value.
+const redisSetScript = `local ttl = tonumber(ARGV[2])
+if ttl > 0 then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ttl)
+else
+	redis.call('SET', KEYS[1], ARGV[1])
+end
+for i = 2, #KEYS do
+	local tagTtl = redis.call('PTTL', KEYS[i])
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if ttl <= 0 then
+		redis.call('PERSIST', KEYS[i])
+	elseif tagTtl == -2 or (tagTtl >= 0 and tagTtl < ttl) then
+		redis.call('PEXPIRE', KEYS[i], ttl)
+	end
+end
+return 1`
+
+// redisInvalidateScript removes the tagged keys and the tag sets (KEYS), atomically, and returns the removed keys
+// count
+const redisInvalidateScript = `local count = 0
+for i = 1, #KEYS do
+	for _, key in ipairs(redis.call('SMEMBERS', KEYS[i])) do
+		count = count + redis.call('DEL', key)
+	end
+	redis.call('DEL', KEYS[i])
+end
+return count`
+
+// Set method caches the json-encoded value of the key, tags and expire duration (no expiry, if zero), atomically.
+// The tag sets expire with the longest-lived tagged value.
+func (cache *RedisCache) Set(key string, value interface{}, expire time.Duration, tags []string) error {
+	jsonVal, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	args := []string{"EVAL", redisSetScript, strconv.Itoa(len(tags) + 1), cache.Prefix + key}
+	for _, tag := range tags {
+		args = append(args, cache.tagKey(tag))
+	}
+	args = append(args, string(jsonVal), strconv.FormatInt(int64(expire/time.Millisecond), 10))
+	if _, err = cache.Do(args...); err != nil {
+		return err
+	}
+	atomic.AddInt64(&cache.stats.sets, 1)
+	return nil
+}
+
+// deleteKeys removes the (prefixed) keys
+func (cache *RedisCache) deleteKeys(keys []string) error {
+	if len(keys) < 1 {
+		return nil
+	}
+	reply, err := cache.Do(append([]string{"DEL"}, keys...)...)
+	if err != nil {
+		return err
+	}
+	if count, ok := reply.(int64); ok {
+		atomic.AddInt64(&cache.stats.invalidations, count)
+	}
+	return nil
+}
+
+// Delete method removes the entries of the keys
+func (cache *RedisCache) Delete(keys ...string) error {
+	var prefixedKeys []string
+	for _, key := range keys {
+		prefixedKeys = append(prefixedKeys, cache.Prefix+key)
+	}
+	return cache.deleteKeys(prefixedKeys)
+}
+
+// InvalidateTags method removes the entries of the tags, and the tag sets, atomically, for all the cache clients
+// (replicas)
+func (cache *RedisCache) InvalidateTags(tags ...string) error {
+	if len(tags) < 1 {
+		return nil
+	}
+	args := []string{"EVAL", redisInvalidateScript, strconv.Itoa(len(tags))}
+	for _, tag := range tags {
+		args = append(args, cache.tagKey(tag))
+	}
+	reply, err := cache.Do(args...)
+	if err != nil {
+		return err
+	}
+	if count, ok := reply.(int64); ok {
+		atomic.AddInt64(&cache.stats.invalidations, count)
+	}
+	return nil
+}
+
+// Stats method returns the cache (client) stats. The server evictions are not reported.
+func (cache *RedisCache) Stats() CacheStatsType {
+	return cache.stats.value()
+}
+
+// Close method closes the idle connections
+func (cache *RedisCache) Close() {
+	for {
+		select {
+		case conn := <-cache.pool:
+			_ = conn.conn.Close()
+		default:
+			return
+		}
+	}
+}
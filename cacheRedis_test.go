@@ -0,0 +1,308 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: Redis-protocol cache test cases, of the in-process fake server
+
+package mccrud
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/abbeymart/mctest"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedisServer is the in-process Redis-protocol server of the strings and sets commands, and the cache scripts
+// (EVAL), without expiry
+type fakeRedisServer struct {
+	listener net.Listener
+	password string
+	values   map[string]string
+	sets     map[string]map[string]struct{}
+	expires  map[string]string
+	mutex    sync.Mutex
+}
+
+// newFakeRedisServer starts the fake server, of the AUTH password, on a local port
+func newFakeRedisServer(password string) (*fakeRedisServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	server := &fakeRedisServer{
+		listener: listener,
+		password: password,
+		values:   map[string]string{},
+		sets:     map[string]map[string]struct{}{},
+		expires:  map[string]string{},
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server, nil
+}
+
+// serve replies to the connection commands
+func (server *fakeRedisServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	authenticated := server.password == ""
+	for {
+		reply, err := readRedisReply(reader)
+		if err != nil {
+			return
+		}
+		items, _ := reply.([]interface{})
+		var args []string
+		for _, item := range items {
+			arg, _ := item.([]byte)
+			args = append(args, string(arg))
+		}
+		if len(args) < 1 {
+			return
+		}
+		command := strings.ToUpper(args[0])
+		if command == "AUTH" {
+			authenticated = len(args) == 2 && args[1] == server.password
+			if !authenticated {
+				_, _ = conn.Write([]byte("-WRONGPASS invalid password\r\n"))
+				continue
+			}
+			_, _ = conn.Write([]byte("+OK\r\n"))
+			continue
+		}
+		if !authenticated {
+			_, _ = conn.Write([]byte("-NOAUTH Authentication required.\r\n"))
+			continue
+		}
+		_, _ = conn.Write([]byte(server.reply(command, args[1:])))
+	}
+}
+
+// reply returns the command RESP reply
+func (server *fakeRedisServer) reply(command string, args []string) string {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	switch command {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		val, ok := server.values[args[0]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%v\r\n%v\r\n", len(val), val)
+	case "SET":
+		server.values[args[0]] = args[1]
+		if len(args) == 4 && strings.ToUpper(args[2]) == "PX" {
+			server.expires[args[0]] = args[3]
+		}
+		return "+OK\r\n"
+	case "DEL":
+		count := 0
+		for _, key := range args {
+			_, isValue := server.values[key]
+			_, isSet := server.sets[key]
+			if isValue || isSet {
+				count++
+			}
+			delete(server.values, key)
+			delete(server.sets, key)
+		}
+		return fmt.Sprintf(":%v\r\n", count)
+	case "SADD":
+		if server.sets[args[0]] == nil {
+			server.sets[args[0]] = map[string]struct{}{}
+		}
+		server.sets[args[0]][args[1]] = struct{}{}
+		return ":1\r\n"
+	case "SMEMBERS":
+		reply := fmt.Sprintf("*%v\r\n", len(server.sets[args[0]]))
+		for member := range server.sets[args[0]] {
+			reply += fmt.Sprintf("$%v\r\n%v\r\n", len(member), member)
+		}
+		return reply
+	case "PEXPIRE":
+		server.expires[args[0]] = args[1]
+		return ":1\r\n"
+	case "PERSIST":
+		delete(server.expires, args[0])
+		return ":1\r\n"
+	case "EVAL":
+		keyCount, _ := strconv.Atoi(args[1])
+		return server.eval(args[0], args[2:2+keyCount], args[2+keyCount:])
+	}
+	return "-ERR unknown command '" + command + "'\r\n"
+}
+
+// pttl returns the expire (ms) of the key: -2, if not exists, and -1, without expiry
+func (server *fakeRedisServer) pttl(key string) int64 {
+	_, isValue := server.values[key]
+	_, isSet := server.sets[key]
+	if !isValue && !isSet {
+		return -2
+	}
+	expire, ok := server.expires[key]
+	if !ok {
+		return -1
+	}
+	ttl, _ := strconv.ParseInt(expire, 10, 64)
+	return ttl
+}
+
+// eval performs the cache scripts (RedisCache Set and InvalidateTags), atomically
+func (server *fakeRedisServer) eval(script string, keys []string, args []string) string {
+	switch script {
+	case redisSetScript:
+		ttl, _ := strconv.ParseInt(args[1], 10, 64)
+		server.values[keys[0]] = args[0]
+		delete(server.expires, keys[0])
+		if ttl > 0 {
+			server.expires[keys[0]] = args[1]
+		}
+		for _, tagKey := range keys[1:] {
+			tagTtl := server.pttl(tagKey)
+			if server.sets[tagKey] == nil {
+				server.sets[tagKey] = map[string]struct{}{}
+			}
+			server.sets[tagKey][keys[0]] = struct{}{}
+			if ttl <= 0 {
+				delete(server.expires, tagKey)
+			} else if tagTtl == -2 || (tagTtl >= 0 && tagTtl < ttl) {
+				server.expires[tagKey] = args[1]
+			}
+		}
+		return ":1\r\n"
+	case redisInvalidateScript:
+		count := 0
+		for _, tagKey := range keys {
+			for key := range server.sets[tagKey] {
+				if _, ok := server.values[key]; ok {
+					count++
+				}
+				delete(server.values, key)
+				delete(server.expires, key)
+			}
+			delete(server.sets, tagKey)
+			delete(server.expires, tagKey)
+		}
+		return fmt.Sprintf(":%v\r\n", count)
+	}
+	return "-NOSCRIPT unknown script\r\n"
+}
+
+func TestRedisCache(t *testing.T) {
+	server, err := newFakeRedisServer("secret")
+	if err != nil {
+		t.Fatalf("fake redis server error: %v", err)
+	}
+	defer server.listener.Close()
+	address := server.listener.Addr().String()
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should set, get and expire the json-encoded values, of the authenticated connection:",
+		TestFunc: func() {
+			cache := NewRedisCache(address, RedisCache{Password: "secret", Prefix: "test:"})
+			defer cache.Close()
+			reply, err := cache.Do("PING")
+			mctest.AssertEquals(t, err, nil, "ping error should be: nil")
+			mctest.AssertEquals(t, reply, "PONG", "ping reply expected")
+			mctest.AssertEquals(t, cache.Set("orders:q1", map[string]interface{}{"id": "order-1"}, 90*time.Second, nil), nil, "set error should be: nil")
+			val, ok := cache.Get("orders:q1")
+			mctest.AssertEquals(t, ok, true, "cached value expected")
+			mctest.AssertEquals(t, string(val.([]byte)), `{"id":"order-1"}`, "json-encoded value expected")
+			server.mutex.Lock()
+			mctest.AssertEquals(t, server.expires["test:orders:q1"], strconv.Itoa(90000), "expire (ms) expected")
+			server.mutex.Unlock()
+			_, ok = cache.Get("orders:q2")
+			mctest.AssertEquals(t, ok, false, "cache miss expected")
+			mctest.AssertEquals(t, cache.Stats(), CacheStatsType{Hits: 1, Misses: 1, Sets: 1}, "cache stats expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should report the server errors, and the authentication failure as cache misses:",
+		TestFunc: func() {
+			cache := NewRedisCache(address, RedisCache{Password: "wrong"})
+			_, err := cache.Do("PING")
+			mctest.AssertEquals(t, err, RedisError("WRONGPASS invalid password"), "auth error expected")
+			_, ok := cache.Get("orders:q1")
+			mctest.AssertEquals(t, ok, false, "cache miss expected")
+			cache = NewRedisCache(address, RedisCache{Password: "secret"})
+			defer cache.Close()
+			_, err = cache.Do("UNKNOWN")
+			mctest.AssertEquals(t, err, RedisError("ERR unknown command 'UNKNOWN'"), "server error expected")
+			_, err = cache.Do("PING")
+			mctest.AssertEquals(t, err, nil, "pooled connection ping error should be: nil")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should invalidate the tagged crud queries, for all the cache clients:",
+		TestFunc: func() {
+			replica1 := NewRedisCache(address, RedisCache{Password: "secret"})
+			replica2 := NewRedisCache(address, RedisCache{Password: "secret"})
+			defer replica1.Close()
+			defer replica2.Close()
+			getResult := GetResultType{Records: []map[string]interface{}{{"id": "order-1"}}, TaskType: "read"}
+			orders := Crud{CrudParamsType: CrudParamsType{TableName: "orders"}, CrudOptionsType: CrudOptionsType{Cache: replica1, CacheTags: []string{"customers"}}}
+			orders.CacheKey = "orders-query-1"
+			orders.setCache(getResult)
+			val, ok := orders.getCache()
+			mctest.AssertEquals(t, ok, true, "cached orders query expected")
+			mctest.AssertEquals(t, val, getResult, "decoded orders query value expected")
+			customers := Crud{CrudParamsType: CrudParamsType{TableName: "customers"}, CrudOptionsType: CrudOptionsType{Cache: replica2}}
+			customers.invalidateCache()
+			_, ok = orders.getCache()
+			mctest.AssertEquals(t, ok, false, "invalidated orders query expected")
+			mctest.AssertEquals(t, replica2.Stats().Invalidations, int64(1), "one invalidation expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should extend the tag set expiry only, to the longest-lived tagged value:",
+		TestFunc: func() {
+			cache := NewRedisCache(address, RedisCache{Password: "secret", Prefix: "ttl:"})
+			defer cache.Close()
+			tagExpire := func() string {
+				server.mutex.Lock()
+				defer server.mutex.Unlock()
+				return server.expires["ttl:tag:orders"]
+			}
+			testCases := []struct {
+				name   string
+				key    string
+				expire time.Duration
+				tagTtl string
+			}{
+				{name: "new tag set", key: "orders:q1", expire: 60 * time.Second, tagTtl: "60000"},
+				{name: "longer-lived value", key: "orders:q2", expire: 120 * time.Second, tagTtl: "120000"},
+				{name: "shorter-lived value", key: "orders:q3", expire: 5 * time.Second, tagTtl: "120000"},
+				{name: "no-expiry value", key: "orders:q4", tagTtl: ""},
+				{name: "expiring value, of the persistent tag set", key: "orders:q5", expire: 10 * time.Second, tagTtl: ""},
+			}
+			for _, testCase := range testCases {
+				mctest.AssertEquals(t, cache.Set(testCase.key, testCase.key, testCase.expire, []string{"orders"}), nil, testCase.name+": set error should be: nil")
+				mctest.AssertEquals(t, tagExpire(), testCase.tagTtl, testCase.name+": tag set expire expected")
+			}
+			mctest.AssertEquals(t, cache.InvalidateTags("orders"), nil, "invalidate error should be: nil")
+			for _, testCase := range testCases {
+				_, ok := cache.Get(testCase.key)
+				mctest.AssertEquals(t, ok, false, testCase.name+": invalidated value expected")
+			}
+			mctest.AssertEquals(t, cache.Stats().Invalidations, int64(len(testCases)), "invalidated values count expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,127 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: crud query-results cache (LRU, no-op and tags invalidation) test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should evict the least recently used entries, and report the stats:",
+		TestFunc: func() {
+			cache := NewLRUCache(2)
+			_ = cache.Set("a", 1, 0, nil)
+			_ = cache.Set("b", 2, 0, nil)
+			_, ok := cache.Get("a")
+			mctest.AssertEquals(t, ok, true, "cached a expected")
+			_ = cache.Set("c", 3, 0, nil)
+			_, ok = cache.Get("b")
+			mctest.AssertEquals(t, ok, false, "evicted b expected")
+			val, _ := cache.Get("c")
+			mctest.AssertEquals(t, val, 3, "cached c value expected")
+			mctest.AssertEquals(t, cache.Stats(), CacheStatsType{Hits: 2, Misses: 1, Sets: 3, Evictions: 1, Entries: 2}, "cache stats expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should expire and invalidate the tagged entries:",
+		TestFunc: func() {
+			cache := NewLRUCache(10)
+			_ = cache.Set("short", 1, time.Millisecond, nil)
+			_ = cache.Set("orders:q1", 1, 0, []string{"orders", "customers"})
+			_ = cache.Set("orders:q2", 2, 0, []string{"orders"})
+			time.Sleep(2 * time.Millisecond)
+			_, ok := cache.Get("short")
+			mctest.AssertEquals(t, ok, false, "expired entry expected")
+			_ = cache.InvalidateTags("customers")
+			_, ok = cache.Get("orders:q1")
+			mctest.AssertEquals(t, ok, false, "invalidated customers-tagged entry expected")
+			_, ok = cache.Get("orders:q2")
+			mctest.AssertEquals(t, ok, true, "orders entry expected")
+			mctest.AssertEquals(t, cache.Stats().Invalidations, int64(1), "one invalidation expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should cache the crud queries, and invalidate by the table and related table writes:",
+		TestFunc: func() {
+			cache := NewLRUCache(10)
+			getResult := GetResultType{Records: []map[string]interface{}{{"id": "order-1"}}}
+			orders := Crud{CrudParamsType: CrudParamsType{TableName: "orders"}, CrudOptionsType: CrudOptionsType{Cache: cache, CacheTags: []string{"customers"}}}
+			orders.CacheKey = "orders-query-1"
+			orders.setCache(getResult)
+			val, ok := orders.getCache()
+			mctest.AssertEquals(t, ok, true, "cached orders query expected")
+			mctest.AssertEquals(t, val, getResult, "cached orders query value expected")
+			customers := Crud{CrudParamsType: CrudParamsType{TableName: "customers"}, CrudOptionsType: CrudOptionsType{Cache: cache}}
+			customers.invalidateCache()
+			_, ok = orders.getCache()
+			mctest.AssertEquals(t, ok, false, "invalidated orders query expected")
+			orders.setCache(getResult)
+			InvalidateTableCache(nil, "orders")
+			_, ok = orders.getCache()
+			mctest.AssertEquals(t, ok, true, "orders query of the crud cache retained, on the DefaultCache invalidation")
+			InvalidateTableCache(orders.Cache, "orders")
+			_, ok = orders.getCache()
+			mctest.AssertEquals(t, ok, false, "invalidated orders query of the crud cache expected")
+			noop := Crud{CrudParamsType: CrudParamsType{TableName: "orders"}, CrudOptionsType: CrudOptionsType{Cache: NewNoopCache()}}
+			noop.setCache(getResult)
+			_, ok = noop.getCache()
+			mctest.AssertEquals(t, ok, false, "no-op cache miss expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should scope the cached queries by the access context (tenant, user and roles):",
+		TestFunc: func() {
+			cache := NewLRUCache(10)
+			getResult := GetResultType{Records: []map[string]interface{}{{"id": "order-1"}}}
+			admin := Crud{CrudParamsType: CrudParamsType{TableName: "orders", UserInfo: UserInfoType{UserId: "admin-1"}}, CrudOptionsType: CrudOptionsType{Cache: cache}}
+			admin.AccessInfo = AccessInfoType{UserId: "admin-1", RoleIds: []string{"admin"}, IsAdmin: true}
+			admin.CacheKey = "orders-query-1"
+			admin.setCache(getResult)
+			user := Crud{CrudParamsType: CrudParamsType{TableName: "orders", UserInfo: UserInfoType{UserId: "user-1"}}, CrudOptionsType: CrudOptionsType{Cache: cache}}
+			user.CacheKey = "orders-query-1"
+			_, ok := user.getCache()
+			mctest.AssertEquals(t, ok, false, "no admin-cached query for the user expected")
+			_, ok = admin.getCache()
+			mctest.AssertEquals(t, ok, true, "admin-cached query expected")
+			admin.AccessInfo.RoleIds = []string{"viewer"}
+			admin.AccessInfo.IsAdmin = false
+			_, ok = admin.getCache()
+			mctest.AssertEquals(t, ok, false, "no cached query for the changed roles expected")
+			mctest.AssertEquals(t, CacheAccessScope("app-1", "user-1", "", []string{"b", "a"}, false), CacheAccessScope("app-1", "user-1", "", []string{"a", "b"}, false), "roles order-independent scope expected")
+			mctest.AssertNotEquals(t, CacheAccessScope("app-1", "user-1", "", nil, false), CacheAccessScope("app-2", "user-1", "", nil, false), "tenant scope expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should share the cached queries of the shared tables, without the user-specific access rules:",
+		TestFunc: func() {
+			cache := NewLRUCache(10)
+			getResult := GetResultType{Records: []map[string]interface{}{{"id": "country-1"}}}
+			options := CrudOptionsType{Cache: cache, CacheSharedTables: []string{"countries"}}
+			user1 := Crud{CrudParamsType: CrudParamsType{TableName: "countries", UserInfo: UserInfoType{UserId: "user-1"}}, CrudOptionsType: options}
+			user1.CacheKey = "countries-query-1"
+			user1.setCache(getResult)
+			user2 := Crud{CrudParamsType: CrudParamsType{TableName: "countries", UserInfo: UserInfoType{UserId: "user-2"}}, CrudOptionsType: options}
+			user2.CacheKey = "countries-query-1"
+			_, ok := user2.getCache()
+			mctest.AssertEquals(t, ok, true, "shared cached query expected")
+			tenant2 := Crud{CrudParamsType: CrudParamsType{TableName: "countries", UserInfo: UserInfoType{UserId: "user-2"}, AppParams: AppParamsType{AppId: "app-2"}}, CrudOptionsType: options}
+			tenant2.CacheKey = "countries-query-1"
+			_, ok = tenant2.getCache()
+			mctest.AssertEquals(t, ok, false, "no shared cached query of the other tenant expected")
+			user2.ShareAccess = true
+			_, ok = user2.getCache()
+			mctest.AssertEquals(t, ok, false, "user-scoped query, with the share access, expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
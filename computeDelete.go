@@ -20,25 +20,26 @@ func deleteErrMessage(errMsg string) DeleteQueryResult {
 	}
 }
 
-// ComputeDeleteQueryById function computes delete SQL scripts by id(s)
-func ComputeDeleteQueryById(tableName string, recordId string) DeleteQueryResult {
+// ComputeDeleteQueryById function computes delete SQL scripts by id(s), scoped to the app/tenant (appId) in multi-tenant mode
+func ComputeDeleteQueryById(tableName string, recordId string, appId string) DeleteQueryResult {
 	if tableName == "" || recordId == "" {
 		return deleteErrMessage("tableName and recordId are required for the delete-by-id operation.")
 	}
 	// validated recordIds, strictly contains string/UUID values, to avoid SQL-injection
-	deleteQuery := fmt.Sprintf("DELETE FROM %v WHERE id=$1", tableName)
+	whereQuery, fieldValues := ComputeTenantWhere("WHERE id=$1", []interface{}{recordId}, appId)
+	deleteQuery := fmt.Sprintf("DELETE FROM %v %v", tableName, whereQuery)
 	return DeleteQueryResult{
 		DeleteQueryObject: DeleteQueryObject{
 			DeleteQuery: deleteQuery,
-			FieldValues: []interface{}{recordId},
+			FieldValues: fieldValues,
 		},
 		Ok: true,
 		Message: "success",
 	}
 }
 
-// ComputeDeleteQueryByIds function computes delete SQL scripts by id(s)
-func ComputeDeleteQueryByIds(tableName string, recordIds []string) DeleteQueryResult {
+// ComputeDeleteQueryByIds function computes delete SQL scripts by id(s), scoped to the app/tenant (appId) in multi-tenant mode
+func ComputeDeleteQueryByIds(tableName string, recordIds []string, appId string) DeleteQueryResult {
 	if tableName == "" || len(recordIds) < 1 {
 		return deleteErrMessage("tableName and recordIds are required for the delete-by-ids operation.")
 	}
@@ -47,34 +48,37 @@ func ComputeDeleteQueryByIds(tableName string, recordIds []string) DeleteQueryRe
 	whereIds := ""
 	idLen := len(recordIds)
 	for idCount, id := range recordIds {
-		whereIds += "'" + id + "'"
+		whereIds += QuoteSQLString(id)
 		if idLen > 1 && idCount < idLen-1 {
 			whereIds += ", "
 		}
 	}
-	deleteQuery := fmt.Sprintf("DELETE FROM %v WHERE id IN (%v)", tableName, whereIds)
+	whereQuery, fieldValues := ComputeTenantWhere(fmt.Sprintf("WHERE id IN (%v)", whereIds), nil, appId)
+	deleteQuery := fmt.Sprintf("DELETE FROM %v %v", tableName, whereQuery)
 	return DeleteQueryResult{
 		DeleteQueryObject: DeleteQueryObject{
 			DeleteQuery: deleteQuery,
-			FieldValues: nil,
+			FieldValues: fieldValues,
 		},
 		Ok: true,
 		Message: "success",
 	}
 }
 
-// ComputeDeleteQueryByParam function computes delete SQL scripts by parameter specifications
-func ComputeDeleteQueryByParam(tableName string, queryParam QueryParamType) DeleteQueryResult {
+// ComputeDeleteQueryByParam function computes delete SQL scripts by parameter specifications, scoped to the
+// app/tenant (appId) in multi-tenant mode
+func ComputeDeleteQueryByParam(tableName string, queryParam QueryParamType, appId string) DeleteQueryResult {
 	if tableName == "" || len(queryParam) < 1 {
 		return deleteErrMessage("tableName and queryParam (where-conditions) are required for the delete-by-param operation.")
 	}
 	whereRes := ComputeWhereQuery(queryParam, 1)
 	if whereRes.Ok {
-		deleteScript := fmt.Sprintf("DELETE FROM %v %v", tableName, whereRes.WhereQueryObject.WhereQuery)
+		whereQuery, fieldValues := ComputeTenantWhere(whereRes.WhereQueryObject.WhereQuery, whereRes.WhereQueryObject.FieldValues, appId)
+		deleteScript := fmt.Sprintf("DELETE FROM %v %v", tableName, whereQuery)
 		return DeleteQueryResult{
 			DeleteQueryObject: DeleteQueryObject{
 				DeleteQuery: deleteScript,
-				FieldValues: whereRes.WhereQueryObject.FieldValues,
+				FieldValues: fieldValues,
 			},
 			Ok: true,
 			Message: "success",
@@ -47,6 +47,9 @@ func ComputeSelectQueryAll(modelRef interface{}, tableName string, options Selec
 	}
 	// get records for the model-defined fields/columns
 	selectQuery := fmt.Sprintf("SELECT %v FROM %v ", fieldText, tableName)
+	// app/tenant condition, in multi-tenant mode
+	whereQuery, fieldValues := ComputeTenantWhere("", nil, options.AppId)
+	selectQuery += whereQuery
 
 	// adjust selectQuery for skip and limit options
 	if options.Limit > 0 {
@@ -59,7 +62,7 @@ func ComputeSelectQueryAll(modelRef interface{}, tableName string, options Selec
 	return SelectQueryResult{
 		SelectQueryObject: SelectQueryObject{
 			SelectQuery: selectQuery,
-			FieldValues: nil,
+			FieldValues: fieldValues,
 		},
 		Ok:      true,
 		Message: "success",
@@ -92,8 +95,9 @@ func ComputeSelectQueryById(modelRef interface{}, tableName string, recordId str
 	}
 	// get record(s) based on projected/provided field names ([]string)
 	selectQuery := fmt.Sprintf("SELECT %v FROM %v ", fieldText, tableName)
-	// from / where condition (where-in-values)
-	selectQuery += fmt.Sprintf("WHERE id=$1")
+	// from / where condition, scoped to the app/tenant in multi-tenant mode
+	whereQuery, fieldValues := ComputeTenantWhere("WHERE id=$1", []interface{}{recordId}, options.AppId)
+	selectQuery += whereQuery
 	// adjust selectQuery for skip and limit options
 	if options.Limit > 0 {
 		selectQuery += fmt.Sprintf(" LIMIT %v", options.Limit)
@@ -105,7 +109,7 @@ func ComputeSelectQueryById(modelRef interface{}, tableName string, recordId str
 	return SelectQueryResult{
 		SelectQueryObject: SelectQueryObject{
 			SelectQuery: selectQuery,
-			FieldValues: []interface{}{recordId},
+			FieldValues: fieldValues,
 		},
 		Ok:      true,
 		Message: "success",
@@ -142,12 +146,13 @@ func ComputeSelectQueryByIds(modelRef interface{}, tableName string, recordIds [
 	whereIds := ""
 	idLen := len(recordIds)
 	for idCount, id := range recordIds {
-		whereIds += "'" + id + "'"
+		whereIds += QuoteSQLString(id)
 		if idLen > 1 && idCount < idLen-1 {
 			whereIds += ", "
 		}
 	}
-	selectQuery += fmt.Sprintf("WHERE id IN (%v)", whereIds)
+	whereQuery, fieldValues := ComputeTenantWhere(fmt.Sprintf("WHERE id IN (%v)", whereIds), nil, options.AppId)
+	selectQuery += whereQuery
 	// adjust selectQuery for skip and limit options
 	if options.Limit > 0 {
 		selectQuery += fmt.Sprintf(" LIMIT %v", options.Limit)
@@ -159,7 +164,7 @@ func ComputeSelectQueryByIds(modelRef interface{}, tableName string, recordIds [
 	return SelectQueryResult{
 		SelectQueryObject: SelectQueryObject{
 			SelectQuery: selectQuery,
-			FieldValues: nil,
+			FieldValues: fieldValues,
 		},
 		Ok:      true,
 		Message: "success",
@@ -196,7 +201,9 @@ func ComputeSelectQueryByParam(modelRef interface{}, tableName string, queryPara
 	// add queryParam-params condition
 	whereRes := ComputeWhereQuery(queryParam, 1)
 	if whereRes.Ok {
-		selectQuery += whereRes.WhereQueryObject.WhereQuery
+		// app/tenant condition, in multi-tenant mode
+		whereQuery, fieldValues := ComputeTenantWhere(whereRes.WhereQueryObject.WhereQuery, whereRes.WhereQueryObject.FieldValues, options.AppId)
+		selectQuery += whereQuery
 		// adjust selectQuery for skip and limit options
 		if options.Limit > 0 {
 			selectQuery += fmt.Sprintf(" LIMIT %v", options.Limit)
@@ -207,7 +214,7 @@ func ComputeSelectQueryByParam(modelRef interface{}, tableName string, queryPara
 		return SelectQueryResult{
 			SelectQueryObject: SelectQueryObject{
 				SelectQuery: selectQuery,
-				FieldValues: whereRes.WhereQueryObject.FieldValues,
+				FieldValues: fieldValues,
 			},
 			Ok:      true,
 			Message: "success",
@@ -217,4 +224,25 @@ func ComputeSelectQueryByParam(modelRef interface{}, tableName string, queryPara
 	}
 }
 
+// ComputeSelectCountQuery compose the SELECT COUNT query of the table-records (total_rows)
+func ComputeSelectCountQuery(tableName string, options SelectQueryOptions) SelectQueryResult {
+	if tableName == "" {
+		return selectErrMessage("tableName is required.")
+	}
+	selectQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", tableName)
+	// app/tenant condition, in multi-tenant mode
+	whereQuery, fieldValues := ComputeTenantWhere("", nil, options.AppId)
+	if whereQuery != "" {
+		selectQuery += " " + whereQuery
+	}
+	return SelectQueryResult{
+		SelectQueryObject: SelectQueryObject{
+			SelectQuery: selectQuery,
+			FieldValues: fieldValues,
+		},
+		Ok:      true,
+		Message: "success",
+	}
+}
+
 // TODO: select-query functions for relational tables (eager & lazy queries) and data aggregation
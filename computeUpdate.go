@@ -32,8 +32,9 @@ func updatesErrMessage(errMsg string) MultiUpdateQueryResult {
 
 // TODO: review/refactor
 
-// ComputeUpdateQuery function computes update SQL script. It returns updateScript, updateValues []interface{} and/or err error
-func ComputeUpdateQuery(tableName string, actionParams ActionParamsType) MultiUpdateQueryResult {
+// ComputeUpdateQuery function computes update SQL script, scoped to the app/tenant (appId) in multi-tenant mode.
+// It returns updateScript, updateValues []interface{} and/or err error
+func ComputeUpdateQuery(tableName string, actionParams ActionParamsType, appId string) MultiUpdateQueryResult {
 	if tableName == "" || len(actionParams) < 1 {
 		return updatesErrMessage("tableName and actionParam are required for the update operation")
 	}
@@ -45,6 +46,10 @@ func ComputeUpdateQuery(tableName string, actionParams ActionParamsType) MultiUp
 		var fieldNames []string
 		var fieldNamesUnderscore []string
 		fieldsLength := len(actParam)
+		// exclude the id (where-condition) field, of the SET fields separators
+		if _, ok := actParam["id"]; ok {
+			fieldsLength = fieldsLength - 1
+		}
 		fieldCount := 0
 		recordId := ""
 		//fmt.Printf("Field-length-start:count: %v:%v \n\n", fieldsLength, fieldCount)
@@ -52,7 +57,6 @@ func ComputeUpdateQuery(tableName string, actionParams ActionParamsType) MultiUp
 			// skip fieldName=="id"
 			if fieldName == "id" {
 				recordId = fmt.Sprintf("%v", actParam["id"])
-				fieldsLength = fieldsLength - 1
 				continue
 			}
 			// next placeholder-value-position
@@ -99,10 +103,11 @@ func ComputeUpdateQuery(tableName string, actionParams ActionParamsType) MultiUp
 		//fmt.Printf("Field-length-start:end: %v:%v \n\n", fieldsLength, fieldCount)
 		// add where condition by id and the placeholder-value position
 		fieldCount += 1
-		updateQuery += fmt.Sprintf(" WHERE id=$%v", fieldCount)
+		// add id-placeholder-value, and the app/tenant condition
+		whereQuery, whereValues := ComputeTenantWhere(fmt.Sprintf("WHERE id=$%v", fieldCount), append(fieldValues, recordId), appId)
+		updateQuery += " " + whereQuery
 		updateQuery += " RETURNING id"
-		// add id-placeholder-value
-		fieldValues = append(fieldValues, recordId)
+		fieldValues = whereValues
 		// update result
 		updateQueryObjects = append(updateQueryObjects, UpdateQueryObject{
 			UpdateQuery: updateQuery,
@@ -119,8 +124,9 @@ func ComputeUpdateQuery(tableName string, actionParams ActionParamsType) MultiUp
 	}
 }
 
-// ComputeUpdateQueryById function computes update SQL scripts by recordId. It returns updateScript, updateValues []interface{} and/or err error
-func ComputeUpdateQueryById(tableName string, actionParam ActionParamType, recordId string) UpdateQueryResult {
+// ComputeUpdateQueryById function computes update SQL scripts by recordId, scoped to the app/tenant (appId) in
+// multi-tenant mode. It returns updateScript, updateValues []interface{} and/or err error
+func ComputeUpdateQueryById(tableName string, actionParam ActionParamType, recordId string, appId string) UpdateQueryResult {
 	if tableName == "" || len(actionParam) < 1 || actionParam == nil || recordId == "" {
 		return updateErrMessage("table-name, recordId and actionParam are required for the update operation")
 	}
@@ -130,11 +136,14 @@ func ComputeUpdateQueryById(tableName string, actionParam ActionParamType, recor
 	var fieldNames []string
 	var fieldNamesUnderscore []string
 	fieldsLength := len(actionParam)
+	// exclude the id (where-condition) field, of the SET fields separators
+	if _, ok := actionParam["id"]; ok {
+		fieldsLength = fieldsLength - 1
+	}
 	fieldCount := 0
 	for fieldName, fieldValue := range actionParam {
 		// skip fieldName=="id"
 		if fieldName == "id" {
-			fieldsLength = fieldsLength - 1
 			continue
 		}
 		// next placeholder-value-position
@@ -180,10 +189,11 @@ func ComputeUpdateQueryById(tableName string, actionParam ActionParamType, recor
 	}
 	// add where condition by id and the placeholder-value position
 	fieldCount += 1
-	updateQuery += fmt.Sprintf(" WHERE id=$%v", fieldCount)
+	// add id-placeholder-value, and the app/tenant condition
+	whereQuery, whereValues := ComputeTenantWhere(fmt.Sprintf("WHERE id=$%v", fieldCount), append(fieldValues, recordId), appId)
+	updateQuery += " " + whereQuery
 	updateQuery += " RETURNING id"
-	// add id-placeholder-value
-	fieldValues = append(fieldValues, recordId)
+	fieldValues = whereValues
 
 	// result
 	return UpdateQueryResult{
@@ -197,8 +207,9 @@ func ComputeUpdateQueryById(tableName string, actionParam ActionParamType, recor
 	}
 }
 
-// ComputeUpdateQueryByIds function computes update SQL scripts by recordIds. It returns updateScript, updateValues []interface{} and/or err error
-func ComputeUpdateQueryByIds(tableName string, actionParam ActionParamType, recordIds []string) UpdateQueryResult {
+// ComputeUpdateQueryByIds function computes update SQL scripts by recordIds, scoped to the app/tenant (appId) in
+// multi-tenant mode. It returns updateScript, updateValues []interface{} and/or err error
+func ComputeUpdateQueryByIds(tableName string, actionParam ActionParamType, recordIds []string, appId string) UpdateQueryResult {
 	if tableName == "" || len(actionParam) < 1 || actionParam == nil || len(recordIds) < 1 {
 		return updateErrMessage("tableName, recordIds and actionParam are required for the update operation")
 	}
@@ -206,23 +217,26 @@ func ComputeUpdateQueryByIds(tableName string, actionParam ActionParamType, reco
 	whereIds := ""
 	idLen := len(recordIds)
 	for idCount, id := range recordIds {
-		whereIds += "'" + id + "'"
+		whereIds += QuoteSQLString(id)
 		if idLen > 1 && idCount < idLen-1 {
 			whereIds += ", "
 		}
 	}
-	whereQuery := fmt.Sprintf(" WHERE id IN(%v)", whereIds)
+	whereQuery := fmt.Sprintf("WHERE id IN(%v)", whereIds)
 	// compute update script and associated place-holder values for the actionParam/record
 	updateQuery := fmt.Sprintf("UPDATE %v SET ", tableName)
 	var fieldValues []interface{}
 	var fieldNames []string
 	var fieldNamesUnderscore []string
 	fieldsLength := len(actionParam)
+	// exclude the id (where-condition) field, of the SET fields separators
+	if _, ok := actionParam["id"]; ok {
+		fieldsLength = fieldsLength - 1
+	}
 	fieldCount := 0
 	for fieldName, fieldValue := range actionParam {
 		// skip fieldName=="id"
 		if fieldName == "id" {
-			fieldsLength = fieldsLength - 1	
 			continue
 		}
 		// next placeholder-value-position
@@ -265,8 +279,9 @@ func ComputeUpdateQueryByIds(tableName string, actionParam ActionParamType, reco
 			updateQuery += ", "
 		}
 	}
-	// add where condition by id and the placeholder-value position
-	updateQuery += whereQuery
+	// add where condition by ids, and the app/tenant condition
+	whereQuery, fieldValues = ComputeTenantWhere(whereQuery, fieldValues, appId)
+	updateQuery += " " + whereQuery
 
 	// result
 	return UpdateQueryResult{
@@ -280,8 +295,9 @@ func ComputeUpdateQueryByIds(tableName string, actionParam ActionParamType, reco
 	}
 }
 
-// ComputeUpdateQueryByParam function computes update SQL scripts by queryParams. It returns updateScript, updateValues []interface{} and/or err error
-func ComputeUpdateQueryByParam(tableName string, actionParam ActionParamType, queryParam QueryParamType) UpdateQueryResult {
+// ComputeUpdateQueryByParam function computes update SQL scripts by queryParams, scoped to the app/tenant (appId) in
+// multi-tenant mode. It returns updateScript, updateValues []interface{} and/or err error
+func ComputeUpdateQueryByParam(tableName string, actionParam ActionParamType, queryParam QueryParamType, appId string) UpdateQueryResult {
 	if tableName == "" || len(actionParam) < 1 || actionParam == nil || len(queryParam) < 1 {
 		return updateErrMessage("table-name, queryParam and actionParam are required for the update operation")
 	}
@@ -291,12 +307,15 @@ func ComputeUpdateQueryByParam(tableName string, actionParam ActionParamType, qu
 	var fieldNames []string
 	var fieldNamesUnderscore []string
 	fieldsLength := len(actionParam)
+	// exclude the id (where-condition) field, of the SET fields separators
+	if _, ok := actionParam["id"]; ok {
+		fieldsLength = fieldsLength - 1
+	}
 	fieldCount := 0
 	//fmt.Printf("Field-length-start:count: %v:%v \n\n", fieldsLength, fieldCount)
 	for fieldName, fieldValue := range actionParam {
 		// skip fieldName=="id"
 		if fieldName == "id" {
-			fieldsLength = fieldsLength - 1
 			continue
 		}
 		// next placeholder-value-position
@@ -347,14 +366,16 @@ func ComputeUpdateQueryByParam(tableName string, actionParam ActionParamType, qu
 		return updateErrMessage(fmt.Sprintf("error computing where-query condition(s): %v", whereRes.Message))
 	}
 
-	updateQuery += " " + whereRes.WhereQueryObject.WhereQuery
+	// app/tenant condition, in multi-tenant mode
+	whereQuery, fieldValues := ComputeTenantWhere(whereRes.WhereQueryObject.WhereQuery, append(fieldValues, whereRes.WhereQueryObject.FieldValues...), appId)
+	updateQuery += " " + whereQuery
 
 	// result
 	return UpdateQueryResult{
 		UpdateQueryObject: UpdateQueryObject{
 			UpdateQuery: updateQuery,
 			FieldNames:  fieldNames,
-			FieldValues: append(fieldValues, whereRes.WhereQueryObject.FieldValues...),
+			FieldValues: fieldValues,
 		},
 		Ok:      true,
 		Message: "success",
@@ -31,6 +31,7 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 	// compute queryParams script from queryParams
 	whereQuery := "WHERE "
 	var fieldValues []interface{}
+	placeholderStart := fieldLength
 	fieldCount := 0
 	whereFieldLength := len(queryParams)
 	for fieldName, fieldValue := range queryParams {
@@ -48,7 +49,7 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 					recIds := "("
 					for i, val := range fVal2 {
 						if valStr, valStrOk := val.(string); valStrOk {
-							recIds += fmt.Sprintf("'%v'", valStr)
+							recIds += QuoteSQLString(valStr)
 						} else {
 							recIds += fmt.Sprintf("%v", val)
 						}
@@ -59,14 +60,14 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 					recIds += ")"
 					// fieldValues.push(`${recIds}`)
 					fieldNameUnderscore := govalidator.CamelCaseToUnderscore(fieldName)
-					whereQuery += fmt.Sprintf("%v IN %v}", fieldNameUnderscore, recIds)
+					whereQuery += fmt.Sprintf("%v IN %v", fieldNameUnderscore, recIds)
 				}
 			} else {
 				// compute IN clause
 				idLen := len(fVal)
 				recIds := "("
 				for i, val := range fVal {
-					recIds += "'" + val + "'"
+					recIds += QuoteSQLString(val)
 					if i < idLen-1 {
 						recIds += ", "
 					}
@@ -74,7 +75,7 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 				recIds += ")"
 				// fieldValues.push(`${recIds}`)
 				fieldNameUnderscore := govalidator.CamelCaseToUnderscore(fieldName)
-				whereQuery += fmt.Sprintf("%v IN %v}", fieldNameUnderscore, recIds)
+				whereQuery += fmt.Sprintf("%v IN %v", fieldNameUnderscore, recIds)
 			}
 		default:
 			switch fieldValue.(type) {
@@ -122,7 +123,8 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 			}
 		}
 		fieldCount += 1
-		fieldLength += 1
+		// next placeholder-value-position, IN-conditions use the literal values
+		fieldLength = placeholderStart + len(fieldValues)
 		if whereFieldLength > 1 && fieldCount < whereFieldLength {
 			whereQuery += " AND "
 		}
@@ -138,3 +140,21 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 		Message: "success",
 	}
 }
+
+// ComputeTenantWhere function scopes the where-query (AND-ed conditions, as computed by ComputeWhereQuery) to the
+// app/tenant (app_id). The app-id placeholder follows the fieldValues. An empty appId (non-tenant mode) returns
+// the whereQuery and fieldValues unchanged.
+func ComputeTenantWhere(whereQuery string, fieldValues []interface{}, appId string) (string, []interface{}) {
+	if appId == "" {
+		return whereQuery, fieldValues
+	}
+	// copy fieldValues, to avoid altering the source values
+	tenantValues := make([]interface{}, 0, len(fieldValues)+1)
+	tenantValues = append(tenantValues, fieldValues...)
+	tenantValues = append(tenantValues, appId)
+	tenantCondition := fmt.Sprintf("app_id=$%v", len(tenantValues))
+	if whereQuery == "" {
+		return "WHERE " + tenantCondition, tenantValues
+	}
+	return whereQuery + " AND " + tenantCondition, tenantValues
+}
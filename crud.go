@@ -15,11 +15,15 @@ import (
 type Crud struct {
 	CrudParamsType
 	CrudOptionsType
-	CreateItems    ActionParamsType
-	UpdateItems    ActionParamsType
-	CurrentRecords []map[string]interface{}
-	TransLog       LogParamX
-	CacheKey       string // Unique for exactly the same query
+	CreateItems      ActionParamsType
+	UpdateItems      ActionParamsType
+	CurrentRecords   []map[string]interface{}
+	TransLog         AuditLogger
+	CacheKey         string // Unique for exactly the same query
+	FieldPermissions []FieldPermissionType
+	AccessInfo       AccessInfoType     // current-user access information, from the access check
+	AccessDecision   AccessDecisionType // explanation of the last access decision
+	AuditRedactor    *AuditRedactor     // audit payload redaction, before the audit logger writes
 }
 
 // NewCrud constructor returns a new crud-instance
@@ -41,6 +45,7 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	crudInstance.Skip = params.Skip
 	crudInstance.Limit = params.Limit
 	crudInstance.AppParams = params.AppParams
+	crudInstance.AuditContext = params.AuditContext
 
 	// crud options
 	crudInstance.MaxQueryLimit = options.MaxQueryLimit
@@ -58,16 +63,59 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	crudInstance.LogCreate = options.LogCreate
 	crudInstance.LogUpdate = options.LogUpdate
 	crudInstance.LogDelete = options.LogDelete
+	crudInstance.LogLogin = options.LogLogin
+	crudInstance.LogLogout = options.LogLogout
+	crudInstance.LoginTimeout = options.LoginTimeout
+	crudInstance.RefreshTimeout = options.RefreshTimeout
 	crudInstance.CheckAccess = options.CheckAccess // Dec 09/2020: user to implement auth as a middleware
 	crudInstance.CacheExpire = options.CacheExpire // cache expire in secs
+	crudInstance.CacheTags = options.CacheTags
+	crudInstance.Cache = options.Cache
+	crudInstance.CacheSharedTables = options.CacheSharedTables
 	crudInstance.BulkCreate = options.BulkCreate
 	crudInstance.ModelOptions = options.ModelOptions
 	crudInstance.FieldSeparator = options.FieldSeparator
+	crudInstance.CheckFieldAccess = options.CheckFieldAccess
+	crudInstance.FieldMaskValue = options.FieldMaskValue
+	crudInstance.TenantMode = options.TenantMode
+	crudInstance.AppTable = options.AppTable
+	crudInstance.Authenticator = options.Authenticator
+	crudInstance.PasswordHasher = options.PasswordHasher
+	crudInstance.Mailer = options.Mailer
+	crudInstance.VerifyTimeout = options.VerifyTimeout
+	crudInstance.UsernameExistsMessage = options.UsernameExistsMessage
+	crudInstance.EmailExistsMessage = options.EmailExistsMessage
+	crudInstance.MsgFrom = options.MsgFrom
+	crudInstance.RoleHierarchy = options.RoleHierarchy
+	crudInstance.RoleInheritTable = options.RoleInheritTable
+	crudInstance.RoleCacheExpire = options.RoleCacheExpire
+	crudInstance.PolicyCheck = options.PolicyCheck
+	crudInstance.PolicyTable = options.PolicyTable
+	crudInstance.Policies = options.Policies
+	crudInstance.LogAccessDenial = options.LogAccessDenial
+	crudInstance.ShareAccess = options.ShareAccess
+	crudInstance.ShareTable = options.ShareTable
+	crudInstance.AutoRegisterService = options.AutoRegisterService
+	crudInstance.RoleDefinitionTable = options.RoleDefinitionTable
+	crudInstance.AccessCache = options.AccessCache
+	crudInstance.AccessCacheExpire = options.AccessCacheExpire
+	crudInstance.AuditDiff = options.AuditDiff
+	crudInstance.AuditHashChain = options.AuditHashChain
+	crudInstance.AuditChainScope = options.AuditChainScope
+	crudInstance.AuditChainTable = options.AuditChainTable
+	crudInstance.AuditWriter = options.AuditWriter
+	crudInstance.AuditLogger = options.AuditLogger
+	crudInstance.AuditLogContext = options.AuditLogContext
+	crudInstance.AuditRedactRules = options.AuditRedactRules
+	crudInstance.AuditRedactKey = options.AuditRedactKey
 
 	// Default values
 	if crudInstance.FieldSeparator == "" {
 		crudInstance.FieldSeparator = "_"
 	}
+	if crudInstance.FieldMaskValue == "" {
+		crudInstance.FieldMaskValue = DefaultFieldMaskValue
+	}
 	if crudInstance.AuditTable == "" {
 		crudInstance.AuditTable = "audits"
 	}
@@ -89,6 +137,33 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	if crudInstance.ServiceTable == "" {
 		crudInstance.ServiceTable = "services"
 	}
+	if crudInstance.AppTable == "" {
+		crudInstance.AppTable = "apps"
+	}
+	if crudInstance.PolicyTable == "" {
+		crudInstance.PolicyTable = "policies"
+	}
+	if crudInstance.ShareTable == "" {
+		crudInstance.ShareTable = "shares"
+	}
+	if crudInstance.RoleDefinitionTable == "" {
+		crudInstance.RoleDefinitionTable = "role_definitions"
+	}
+	if crudInstance.RoleInheritTable == "" {
+		crudInstance.RoleInheritTable = "role_inherits"
+	}
+	if crudInstance.RoleCacheExpire <= 0 {
+		crudInstance.RoleCacheExpire = 300 // 300 secs, 5 minutes
+	}
+	if crudInstance.AccessCacheExpire <= 0 {
+		crudInstance.AccessCacheExpire = 60 // 60 secs, 1 minute
+	}
+	if crudInstance.AuditChainScope != AuditChainGlobalScope {
+		crudInstance.AuditChainScope = AuditChainTableScope
+	}
+	if crudInstance.AuditChainTable == "" {
+		crudInstance.AuditChainTable = "audit_chains"
+	}
 	if crudInstance.AuditDb == nil {
 		crudInstance.AuditDb = crudInstance.AppDb
 	}
@@ -110,6 +185,27 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	if crudInstance.CacheExpire <= 0 {
 		crudInstance.CacheExpire = 300 // 300 secs, 5 minutes
 	}
+	if crudInstance.Cache == nil {
+		crudInstance.Cache = DefaultCache
+	}
+	if crudInstance.LoginTimeout <= 0 {
+		crudInstance.LoginTimeout = 3600 // 3600 secs, 1 hour
+	}
+	if crudInstance.RefreshTimeout <= 0 {
+		crudInstance.RefreshTimeout = 604800 // 604800 secs, 7 days
+	}
+	if crudInstance.PasswordHasher == nil {
+		crudInstance.PasswordHasher = NewBcryptPasswordHasher(0)
+	}
+	if crudInstance.VerifyTimeout <= 0 {
+		crudInstance.VerifyTimeout = 86400 // 86400 secs, 1 day
+	}
+	if crudInstance.UsernameExistsMessage == "" {
+		crudInstance.UsernameExistsMessage = "Username already exists. Please use a different username"
+	}
+	if crudInstance.EmailExistsMessage == "" {
+		crudInstance.EmailExistsMessage = "Email already exists. Please use a different email or reset your password"
+	}
 	// Compute CacheKey from TableName, QueryParams, SortParams, ProjectParams and RecordIds
 	qParam, _ := json.Marshal(params.QueryParams)
 	sParam, _ := json.Marshal(params.SortParams)
@@ -117,9 +213,35 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	dIds, _ := json.Marshal(params.RecordIds)
 	//crudInstance.CacheKey = params.TableName + string(qParam) + string(sParam) + string(pParam) + string(dIds)
 	crudInstance.CacheKey = fmt.Sprintf("%v-%v-%v-%v-%v-%v-%v", params.TableName, string(qParam), string(sParam), string(pParam), string(dIds), crudInstance.Skip, crudInstance.Limit)
+	if crudInstance.TenantMode {
+		crudInstance.CacheKey = fmt.Sprintf("%v-%v", crudInstance.CacheKey, params.AppParams.AppId)
+	}
 
 	// Audit/TransLog instance
-	crudInstance.TransLog = NewAuditLogx(crudInstance.AuditDb, crudInstance.AuditTable)
+	if crudInstance.AuditLogger != nil {
+		crudInstance.TransLog = crudInstance.AuditLogger
+	} else {
+		transLog := NewAuditLogx(crudInstance.AuditDb, crudInstance.AuditTable)
+		transLog.HashChain = crudInstance.AuditHashChain
+		transLog.ChainScope = crudInstance.AuditChainScope
+		transLog.ChainTable = crudInstance.AuditChainTable
+		transLog.AuditDiff = crudInstance.AuditDiff
+		transLog.Writer = crudInstance.AuditWriter
+		transLog.LogContext = crudInstance.AuditLogContext
+		crudInstance.TransLog = transLog
+	}
+	// audit context of the crud-task audit-log entries, read at the log time (e.g. per-request AuditContext changes)
+	crudInstance.TransLog = contextAuditLogger{
+		Logger: crudInstance.TransLog,
+		Crud:   crudInstance,
+	}
+	// audit payload redaction, of all the audit loggers (sinks)
+	crudInstance.AuditRedactor = NewAuditRedactor(crudInstance.AuditRedactRules)
+	crudInstance.AuditRedactor.HashKey = crudInstance.AuditRedactKey
+	crudInstance.TransLog = RedactAuditLogger{
+		Logger:   crudInstance.TransLog,
+		Redactor: crudInstance.AuditRedactor,
+	}
 
 	return crudInstance
 }
@@ -133,6 +255,12 @@ func (crud Crud) String() string {
 
 // SaveRecord method creates new record(s) or updates existing record(s)
 func (crud *Crud) SaveRecord() mcresponse.ResponseMessage {
+	// validate app-access, in multi-tenant mode
+	if crud.TenantMode {
+		if appRes := crud.CheckAppAccess(); appRes.Code != "success" {
+			return appRes
+		}
+	}
 	//  compute taskType-records from actionParams: create or update
 	var (
 		createRecs = ActionParamsType{} // records without id field-value
@@ -207,6 +335,13 @@ func (crud *Crud) SaveRecord() mcresponse.ResponseMessage {
 			Value:   nil,
 		})
 	}
+	// app/tenant (app_id) may not be changed by update-tasks
+	if crud.TenantMode {
+		for _, rec := range updateRecs {
+			delete(rec, "appId")
+			delete(rec, "app_id")
+		}
+	}
 	// set task-type
 	if len(createRecs) > 0 {
 		crud.TaskType = CreateTask
@@ -222,13 +357,10 @@ func (crud *Crud) SaveRecord() mcresponse.ResponseMessage {
 	// create/insert new record(s)
 	if crud.TaskType == CreateTask && len(createRecs) > 0 {
 		// check task-permission
-		if crud.CheckAccess {
-			accessRes := crud.CheckTaskAccess()
-			if accessRes.Code != "success" {
-				return accessRes
-			}
+		if accessRes := crud.TaskAccess(crud.TaskType, crud.CheckTaskAccess); accessRes.Code != "success" {
+			return accessRes
 		}
-		return crud.Create(createRecs)
+		return crud.accessTablesWrite(crud.Create(createRecs))
 	}
 
 	// update existing record(s), by record-id(s) or queryParams | or perform multiple updates
@@ -236,45 +368,41 @@ func (crud *Crud) SaveRecord() mcresponse.ResponseMessage {
 		if len(updateRecs) == 1 {
 			if len(crud.RecordIds) == 1 {
 				// check task-permission
-				if crud.CheckAccess {
-					accessRes := crud.TaskPermissionById(crud.TaskType)
-					if accessRes.Code != "success" {
-						return accessRes
-					}
+				if accessRes := crud.TaskAccess(crud.TaskType, func() mcresponse.ResponseMessage {
+					return crud.TaskPermissionById(crud.TaskType)
+				}); accessRes.Code != "success" {
+					return accessRes
 				}
-				return crud.UpdateById(updateRecs[0], crud.RecordIds[0])
+				return crud.accessTablesWrite(crud.UpdateById(updateRecs[0], crud.RecordIds[0]))
 			}
 			if len(crud.RecordIds) > 1 {
 				// check task-permission
-				if crud.CheckAccess {
-					accessRes := crud.TaskPermissionById(crud.TaskType)
-					if accessRes.Code != "success" {
-						return accessRes
-					}
+				if accessRes := crud.TaskAccess(crud.TaskType, func() mcresponse.ResponseMessage {
+					return crud.TaskPermissionById(crud.TaskType)
+				}); accessRes.Code != "success" {
+					return accessRes
 				}
-				return crud.UpdateByIds(updateRecs[0])
+				return crud.accessTablesWrite(crud.UpdateByIds(updateRecs[0]))
 			}
 			if len(crud.QueryParams) > 0 {
 				// check task-permission
-				if crud.CheckAccess {
-					accessRes := crud.TaskPermissionByParam(crud.TaskType)
-					if accessRes.Code != "success" {
-						return accessRes
-					}
+				if accessRes := crud.TaskAccess(crud.TaskType, func() mcresponse.ResponseMessage {
+					return crud.TaskPermissionByParam(crud.TaskType)
+				}); accessRes.Code != "success" {
+					return accessRes
 				}
-				return crud.UpdateByParam(updateRecs[0])
+				return crud.accessTablesWrite(crud.UpdateByParam(updateRecs[0]))
 			}
 		}
 		// update multiple records
 		crud.RecordIds = recIds
 		// check task-permission
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermissionById(crud.TaskType)
-			if accessRes.Code != "success" {
-				return accessRes
-			}
+		if accessRes := crud.TaskAccess(crud.TaskType, func() mcresponse.ResponseMessage {
+			return crud.TaskPermissionById(crud.TaskType)
+		}); accessRes.Code != "success" {
+			return accessRes
 		}
-		return crud.Update(updateRecs)
+		return crud.accessTablesWrite(crud.Update(updateRecs))
 	}
 	// otherwise, return saveError
 	return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
@@ -285,32 +413,35 @@ func (crud *Crud) SaveRecord() mcresponse.ResponseMessage {
 
 // DeleteRecord method deletes/removes record(s) by recordIds or queryParams
 func (crud *Crud) DeleteRecord() mcresponse.ResponseMessage {
+	// validate app-access, in multi-tenant mode
+	if crud.TenantMode {
+		if appRes := crud.CheckAppAccess(); appRes.Code != "success" {
+			return appRes
+		}
+	}
 	if len(crud.RecordIds) == 1 {
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermissionById(DeleteTask)
-			if accessRes.Code != "success" {
-				return accessRes
-			}
+		if accessRes := crud.TaskAccess(DeleteTask, func() mcresponse.ResponseMessage {
+			return crud.TaskPermissionById(DeleteTask)
+		}); accessRes.Code != "success" {
+			return accessRes
 		}
-		return crud.DeleteById(crud.RecordIds[0])
+		return crud.accessTablesWrite(crud.DeleteById(crud.RecordIds[0]))
 	}
 	if len(crud.RecordIds) > 1 {
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermissionById(DeleteTask)
-			if accessRes.Code != "success" {
-				return accessRes
-			}
+		if accessRes := crud.TaskAccess(DeleteTask, func() mcresponse.ResponseMessage {
+			return crud.TaskPermissionById(DeleteTask)
+		}); accessRes.Code != "success" {
+			return accessRes
 		}
-		return crud.DeleteByIds()
+		return crud.accessTablesWrite(crud.DeleteByIds())
 	}
 	if crud.QueryParams != nil && len(crud.QueryParams) > 0 {
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermissionByParam(DeleteTask)
-			if accessRes.Code != "success" {
-				return accessRes
-			}
+		if accessRes := crud.TaskAccess(DeleteTask, func() mcresponse.ResponseMessage {
+			return crud.TaskPermissionByParam(DeleteTask)
+		}); accessRes.Code != "success" {
+			return accessRes
 		}
-		return crud.DeleteByParam()
+		return crud.accessTablesWrite(crud.DeleteByParam())
 	}
 	// delete-all ***RESTRICTED***
 	// otherwise return error
@@ -322,44 +453,58 @@ func (crud *Crud) DeleteRecord() mcresponse.ResponseMessage {
 
 // GetRecord method fetches records by recordIds, queryParams or all
 func (crud *Crud) GetRecord() mcresponse.ResponseMessage {
+	// validate app-access, in multi-tenant mode
+	if crud.TenantMode {
+		if appRes := crud.CheckAppAccess(); appRes.Code != "success" {
+			return appRes
+		}
+	}
 	if len(crud.RecordIds) == 1 {
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermissionById(ReadTask)
-			if accessRes.Code != "success" {
-				return accessRes
-			}
+		if accessRes := crud.TaskAccess(ReadTask, func() mcresponse.ResponseMessage {
+			return crud.TaskPermissionById(ReadTask)
+		}); accessRes.Code != "success" {
+			return accessRes
 		}
-		return crud.GetById(crud.RecordIds[0])
+		return crud.FieldReadAccess(crud.PolicyReadAccess(func() mcresponse.ResponseMessage {
+			return crud.GetById(crud.RecordIds[0])
+		}))
 	}
 	if len(crud.RecordIds) > 1 {
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermissionById(ReadTask)
-			if accessRes.Code != "success" {
-				return accessRes
-			}
+		if accessRes := crud.TaskAccess(ReadTask, func() mcresponse.ResponseMessage {
+			return crud.TaskPermissionById(ReadTask)
+		}); accessRes.Code != "success" {
+			return accessRes
 		}
-		return crud.GetByIds()
+		return crud.FieldReadAccess(crud.PolicyReadAccess(func() mcresponse.ResponseMessage {
+			return crud.GetByIds()
+		}))
 	}
 	if crud.QueryParams != nil && len(crud.QueryParams) > 0 {
-		if crud.CheckAccess {
-			accessRes := crud.TaskPermissionByParam(ReadTask)
-			if accessRes.Code != "success" {
-				return accessRes
-			}
-		}
-		return crud.GetByParam()
-	}
-	if crud.CheckAccess {
-		accessRes := crud.CheckTaskAccess()
-		if accessRes.Code != "success" {
+		if accessRes := crud.TaskAccess(ReadTask, func() mcresponse.ResponseMessage {
+			return crud.TaskPermissionByParam(ReadTask)
+		}); accessRes.Code != "success" {
 			return accessRes
 		}
+		return crud.FieldReadAccess(crud.PolicyReadAccess(func() mcresponse.ResponseMessage {
+			return crud.GetByParam()
+		}))
 	}
-	return crud.GetAll()
+	if accessRes := crud.TaskAccess(ReadTask, crud.CheckTaskAccess); accessRes.Code != "success" {
+		return accessRes
+	}
+	return crud.FieldReadAccess(crud.PolicyReadAccess(func() mcresponse.ResponseMessage {
+		return crud.GetAll()
+	}))
 }
 
 // GetRecords method fetches records by recordIds, queryParams or all - lookup-items (no-access-constraint)
 func (crud *Crud) GetRecords() mcresponse.ResponseMessage {
+	// validate app-access, in multi-tenant mode
+	if crud.TenantMode {
+		if appRes := crud.CheckAppAccess(); appRes.Code != "success" {
+			return appRes
+		}
+	}
 	if len(crud.RecordIds) == 1 {
 		return crud.GetById(crud.RecordIds[0])
 	}
@@ -6,7 +6,6 @@ package mccrud
 
 import (
 	"fmt"
-	"github.com/abbeymart/mccache"
 	"github.com/abbeymart/mcresponse"
 )
 
@@ -20,7 +19,7 @@ func (crud *Crud) DeleteById(id string) mcresponse.ResponseMessage {
 		crud.CurrentRecords = value.Records
 	}
 	// compute delete query by record-id
-	deleteQueryRes := ComputeDeleteQueryById(crud.TableName, id)
+	deleteQueryRes := ComputeDeleteQueryById(crud.TableName, id, crud.TenantId())
 	if !deleteQueryRes.Ok {
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: deleteQueryRes.Message,
@@ -36,7 +35,7 @@ func (crud *Crud) DeleteById(id string) mcresponse.ResponseMessage {
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.CacheKey, "key")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -78,7 +77,7 @@ func (crud *Crud) DeleteByIds() mcresponse.ResponseMessage {
 		crud.CurrentRecords = value.Records
 	}
 	// compute delete query by record-ids
-	deleteQueryRes := ComputeDeleteQueryByIds(crud.TableName, crud.RecordIds)
+	deleteQueryRes := ComputeDeleteQueryByIds(crud.TableName, crud.RecordIds, crud.TenantId())
 	if !deleteQueryRes.Ok {
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: deleteQueryRes.Message,
@@ -93,7 +92,7 @@ func (crud *Crud) DeleteByIds() mcresponse.ResponseMessage {
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.CacheKey, "key")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -135,7 +134,7 @@ func (crud *Crud) DeleteByParam() mcresponse.ResponseMessage {
 		crud.CurrentRecords = value.Records
 	}
 	// compute delete query by query-params
-	deleteQueryRes := ComputeDeleteQueryByParam(crud.TableName, crud.QueryParams)
+	deleteQueryRes := ComputeDeleteQueryByParam(crud.TableName, crud.QueryParams, crud.TenantId())
 	//fmt.Printf("delete-by-param-query: %v \n", deleteQueryRes.DeleteQueryObject.DeleteQuery)
 	if !deleteQueryRes.Ok {
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
@@ -151,7 +150,7 @@ func (crud *Crud) DeleteByParam() mcresponse.ResponseMessage {
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.CacheKey, "key")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -190,8 +189,10 @@ func (crud *Crud) DeleteAll() mcresponse.ResponseMessage {
 	// ***** perform DELETE-ALL-RECORDS FROM A TABLE, IF RELATIONS/CONSTRAINTS PERMIT *****
 	// ***** && IF-AND-ONLY-IF-YOU-KNOW-WHAT-YOU-ARE-DOING && AT-YOUR-OWN-RISK *****
 	// compute delete query
-	delQuery := fmt.Sprintf("DELETE FROM %v", crud.TableName)
-	res, delErr := crud.AppDb.Exec(delQuery)
+	// scope delete-query to the app/tenant, in multi-tenant mode
+	whereQuery, delValues := crud.TenantWhere("", nil)
+	delQuery := fmt.Sprintf("DELETE FROM %v %v", crud.TableName, whereQuery)
+	res, delErr := crud.AppDb.Exec(delQuery, delValues...)
 	if delErr != nil {
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error deleting record(s): %v", delErr.Error()),
@@ -199,7 +200,7 @@ func (crud *Crud) DeleteAll() mcresponse.ResponseMessage {
 		})
 	}
 	// delete cache, by key (TableName)
-	_ = mccache.DeleteHashCache(crud.TableName, crud.CacheKey, "key")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -0,0 +1,175 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: field/column-level (read/write) permissions, for role-services
+
+package mccrud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/asaskevich/govalidator"
+)
+
+// DefaultFieldMaskValue is the value returned for masked (read) fields
+const DefaultFieldMaskValue = "********"
+
+// fields managed by the crud-operations, exempted from field-write permission
+var fieldWriteExempted = []string{"id", "created_by", "created_at", "updated_by", "updated_at"}
+
+// ParseFieldPermissions decodes the json-array (field_permissions column-value) of FieldPermissionType
+func ParseFieldPermissions(jsonStr string) ([]FieldPermissionType, error) {
+	var fieldPermissions []FieldPermissionType
+	if err := json.Unmarshal([]byte(jsonStr), &fieldPermissions); err != nil {
+		return nil, errors.New(fmt.Sprintf("error parsing field-permissions: %v", err.Error()))
+	}
+	return fieldPermissions, nil
+}
+
+// FieldPermissionsMap returns the field-permissions by underscore field-name, for camelCase and underscore lookups.
+// For duplicate field-names (e.g. from multiple role-services), the most permissive permission applies.
+func FieldPermissionsMap(fieldPermissions []FieldPermissionType) map[string]FieldPermissionType {
+	permMap := map[string]FieldPermissionType{}
+	for _, fieldPerm := range fieldPermissions {
+		if fieldPerm.FieldName == "" {
+			continue
+		}
+		fieldName := govalidator.CamelCaseToUnderscore(fieldPerm.FieldName)
+		if perm, ok := permMap[fieldName]; ok {
+			perm.CanRead = perm.CanRead || fieldPerm.CanRead
+			perm.CanWrite = perm.CanWrite || fieldPerm.CanWrite
+			perm.MaskRead = perm.MaskRead || fieldPerm.MaskRead
+			permMap[fieldName] = perm
+		} else {
+			permMap[fieldName] = fieldPerm
+		}
+	}
+	return permMap
+}
+
+// CheckWriteFields returns the error-messages (by field-name) for the actionParams fields that cannot be written.
+// Fields without permission-specification are permitted.
+func CheckWriteFields(actionParams ActionParamsType, fieldPermissions []FieldPermissionType) MessageObject {
+	errMsg := MessageObject{}
+	permMap := FieldPermissionsMap(fieldPermissions)
+	if len(permMap) < 1 {
+		return errMsg
+	}
+	for _, rec := range actionParams {
+		for fieldName := range rec {
+			fieldNameUnderscore := govalidator.CamelCaseToUnderscore(fieldName)
+			if ArrayStringContains(fieldWriteExempted, fieldNameUnderscore) {
+				continue
+			}
+			if perm, ok := permMap[fieldNameUnderscore]; ok && !perm.CanWrite {
+				errMsg[fieldName] = fmt.Sprintf("you are not permitted to write/update the field: %v", fieldName)
+			}
+		}
+	}
+	return errMsg
+}
+
+// CheckReadFields returns the error-messages (by field-name) for the query, sort and projection params fields that
+// cannot be read, so that the un-readable fields may not be filtered or sorted by (read) tasks.
+// Fields without permission-specification are permitted.
+func CheckReadFields(queryParams QueryParamType, sortParams SortParamType, projectParams ProjectParamType, fieldPermissions []FieldPermissionType) MessageObject {
+	errMsg := MessageObject{}
+	permMap := FieldPermissionsMap(fieldPermissions)
+	if len(permMap) < 1 {
+		return errMsg
+	}
+	var fieldNames []string
+	for fieldName := range queryParams {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	for fieldName := range sortParams {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	for fieldName := range projectParams {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	for _, fieldName := range fieldNames {
+		if perm, ok := permMap[govalidator.CamelCaseToUnderscore(fieldName)]; ok && !perm.CanRead {
+			errMsg[fieldName] = fmt.Sprintf("you are not permitted to query/sort/project the field: %v", fieldName)
+		}
+	}
+	return errMsg
+}
+
+// FilterReadFields returns copies of the records, with the un-readable fields removed or masked.
+// Fields without permission-specification are permitted.
+func FilterReadFields(records []map[string]interface{}, fieldPermissions []FieldPermissionType, maskValue string) []map[string]interface{} {
+	permMap := FieldPermissionsMap(fieldPermissions)
+	if len(permMap) < 1 {
+		return records
+	}
+	if maskValue == "" {
+		maskValue = DefaultFieldMaskValue
+	}
+	var filteredRecords []map[string]interface{}
+	for _, rec := range records {
+		// copy record, to avoid altering (cached) source records
+		filteredRec := map[string]interface{}{}
+		for fieldName, fieldValue := range rec {
+			perm, ok := permMap[govalidator.CamelCaseToUnderscore(fieldName)]
+			if !ok || perm.CanRead {
+				filteredRec[fieldName] = fieldValue
+			} else if perm.MaskRead {
+				filteredRec[fieldName] = maskValue
+			}
+		}
+		filteredRecords = append(filteredRecords, filteredRec)
+	}
+	return filteredRecords
+}
+
+// CheckFieldWriteAccess method validates the actionParams against the field-permissions of the current-user/role.
+// It requires a prior access check (CheckTaskAccess, TaskPermissionById or TaskPermissionByParam).
+func (crud *Crud) CheckFieldWriteAccess() mcresponse.ResponseMessage {
+	if !crud.CheckAccess || !crud.CheckFieldAccess || len(crud.FieldPermissions) < 1 {
+		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+			Message: "Field-level write access permitted.",
+			Value:   nil,
+		})
+	}
+	if errMsg := CheckWriteFields(crud.ActionParams, crud.FieldPermissions); len(errMsg) > 0 {
+		return GetParamsMessage(errMsg)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Field-level write access permitted.",
+		Value:   nil,
+	})
+}
+
+// CheckFieldReadAccess method validates the query, sort and projection params against the field-permissions of the
+// current-user/role. It requires a prior access check (CheckTaskAccess, TaskPermissionById or TaskPermissionByParam).
+func (crud *Crud) CheckFieldReadAccess() mcresponse.ResponseMessage {
+	if !crud.CheckAccess || !crud.CheckFieldAccess || len(crud.FieldPermissions) < 1 {
+		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+			Message: "Field-level read access permitted.",
+			Value:   nil,
+		})
+	}
+	if errMsg := CheckReadFields(crud.QueryParams, crud.SortParams, crud.ProjectParams, crud.FieldPermissions); len(errMsg) > 0 {
+		return GetParamsMessage(errMsg)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Field-level read access permitted.",
+		Value:   nil,
+	})
+}
+
+// FieldReadAccess method removes/masks the un-readable fields from the get/read-task records response
+func (crud *Crud) FieldReadAccess(res mcresponse.ResponseMessage) mcresponse.ResponseMessage {
+	if !crud.CheckAccess || !crud.CheckFieldAccess || len(crud.FieldPermissions) < 1 || res.Code != "success" {
+		return res
+	}
+	result, ok := res.Value.(GetResultType)
+	if !ok {
+		return res
+	}
+	result.Records = FilterReadFields(result.Records, crud.FieldPermissions, crud.FieldMaskValue)
+	res.Value = result
+	return res
+}
@@ -0,0 +1,82 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: field-level permissions test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestFieldAccess(t *testing.T) {
+	fieldPerms, fpErr := ParseFieldPermissions(`[{"fieldName": "salary", "canRead": false, "canWrite": false}, {"fieldName": "email", "canRead": false, "canWrite": false, "maskRead": true}, {"fieldName": "jobTitle", "canRead": true, "canWrite": false}]`)
+	records := []map[string]interface{}{
+		{"id": "1", "name": "Abi", "salary": 5000, "email": "abi@mconnect.biz", "jobTitle": "Engineer"},
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should parse field-permissions from json-array:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, fpErr, nil, "no parse error expected")
+			mctest.AssertEquals(t, len(fieldPerms), 3, "three field-permissions expected")
+			_, err := ParseFieldPermissions(`{"fieldName": "salary"}`)
+			mctest.AssertEquals(t, err != nil, true, "parse error expected for non-array value")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should strip and mask un-readable fields, without altering source records:",
+		TestFunc: func() {
+			res := FilterReadFields(records, fieldPerms, "")
+			_, salaryOk := res[0]["salary"]
+			mctest.AssertEquals(t, salaryOk, false, "salary field should be removed")
+			mctest.AssertEquals(t, res[0]["email"], DefaultFieldMaskValue, "email field should be masked")
+			mctest.AssertEquals(t, res[0]["jobTitle"], "Engineer", "jobTitle field should be readable")
+			mctest.AssertEquals(t, res[0]["name"], "Abi", "unspecified field should be readable")
+			mctest.AssertEquals(t, records[0]["salary"], 5000, "source record should not be altered")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return per-field error-messages for un-writable fields:",
+		TestFunc: func() {
+			errMsg := CheckWriteFields(ActionParamsType{
+				{"id": "1", "name": "Abi", "salary": 6000, "job_title": "Manager", "updatedBy": "1"},
+			}, fieldPerms)
+			mctest.AssertEquals(t, len(errMsg), 2, "two field errors expected")
+			_, salaryOk := errMsg["salary"]
+			_, jobTitleOk := errMsg["job_title"]
+			mctest.AssertEquals(t, salaryOk, true, "salary field error expected")
+			mctest.AssertEquals(t, jobTitleOk, true, "job_title field error expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return per-field error-messages for the un-readable query, sort and projection fields:",
+		TestFunc: func() {
+			errMsg := CheckReadFields(QueryParamType{"salary": 100000, "name": "Abi"}, SortParamType{"email": 1, "jobTitle": -1}, ProjectParamType{"salary": true, "name": true}, fieldPerms)
+			mctest.AssertEquals(t, len(errMsg), 2, "two field errors expected")
+			_, salaryOk := errMsg["salary"]
+			_, emailOk := errMsg["email"]
+			mctest.AssertEquals(t, salaryOk, true, "salary (query and projection) field error expected")
+			mctest.AssertEquals(t, emailOk, true, "email (masked, sort) field error expected")
+			mctest.AssertEquals(t, len(CheckReadFields(QueryParamType{"job_title": "Engineer"}, nil, nil, fieldPerms)), 0, "no field errors expected, of the readable fields")
+			crud := Crud{CrudParamsType: CrudParamsType{QueryParams: QueryParamType{"salary": 100000}}, CrudOptionsType: CrudOptionsType{CheckAccess: true, CheckFieldAccess: true}, FieldPermissions: fieldPerms}
+			mctest.AssertEquals(t, crud.CheckFieldReadAccess().Code, "validateError", "un-readable query field error expected")
+			crud.QueryParams = QueryParamType{"name": "Abi"}
+			mctest.AssertEquals(t, crud.CheckFieldReadAccess().Code, "success", "readable query field expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should apply the most permissive of duplicate field-permissions:",
+		TestFunc: func() {
+			permMap := FieldPermissionsMap(append(fieldPerms, FieldPermissionType{FieldName: "salary", CanRead: true}))
+			mctest.AssertEquals(t, permMap["salary"].CanRead, true, "salary should be readable")
+			mctest.AssertEquals(t, permMap["salary"].CanWrite, false, "salary should not be writable")
+		},
+	})
+
+	mctest.PostTestResult()
+}
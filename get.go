@@ -7,7 +7,6 @@ package mccrud
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/abbeymart/mccache"
 	"github.com/abbeymart/mcresponse"
 	"github.com/jmoiron/sqlx"
 )
@@ -17,9 +16,7 @@ import (
 
 func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 	// check cache
-	getCacheRes := mccache.GetHashCache(crud.CacheKey, crud.TableName)
-	val, ok := getCacheRes.Value.(GetResultType)
-	if getCacheRes.Ok && ok && len(val.Records) > 0 {
+	if val, ok := crud.getCache(); ok {
 		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 			Message: "records successfully retrieved from the cache",
 			Value:   val,
@@ -29,6 +26,7 @@ func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
 		Limit: crud.Limit,
+		AppId: crud.TenantId(),
 	}
 	getQueryRes := ComputeSelectQueryById(crud.ModelRef, crud.TableName, id, selectOptions)
 	if !getQueryRes.Ok {
@@ -40,8 +38,8 @@ func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 	//fmt.Printf("Get-query-by-id: %v \n", getQueryRes.SelectQueryObject.SelectQuery )
 	// totalRecordsCount from the table
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	countQueryRes := ComputeSelectCountQuery(crud.TableName, selectOptions)
+	tRowErr := crud.AppDb.QueryRowx(countQueryRes.SelectQueryObject.SelectQuery, countQueryRes.SelectQueryObject.FieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -133,7 +131,7 @@ func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, uint(crud.CacheExpire))
+	crud.setCache(getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
@@ -143,9 +141,7 @@ func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 
 func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 	// check cache
-	getCacheRes := mccache.GetHashCache(crud.CacheKey, crud.TableName)
-	val, ok := getCacheRes.Value.(GetResultType)
-	if getCacheRes.Ok && ok && len(val.Records) > 0 {
+	if val, ok := crud.getCache(); ok {
 		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 			Message: "records successfully retrieved from the cache",
 			Value:   val,
@@ -155,6 +151,7 @@ func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
 		Limit: crud.Limit,
+		AppId: crud.TenantId(),
 	}
 	getQueryRes := ComputeSelectQueryById(crud.ModelRef, crud.TableName, id, selectOptions)
 	if !getQueryRes.Ok {
@@ -166,8 +163,8 @@ func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 	//fmt.Printf("Get-query-by-id: %v \n", getQueryRes.SelectQueryObject.SelectQuery )
 	// totalRecordsCount from the table
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	countQueryRes := ComputeSelectCountQuery(crud.TableName, selectOptions)
+	tRowErr := crud.AppDb.QueryRowx(countQueryRes.SelectQueryObject.SelectQuery, countQueryRes.SelectQueryObject.FieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -250,7 +247,7 @@ func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, uint(crud.CacheExpire))
+	crud.setCache(getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
@@ -262,9 +259,7 @@ func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 // constrained by optional skip and limit parameters
 func (crud Crud) GetByIds() mcresponse.ResponseMessage {
 	// check cache
-	getCacheRes := mccache.GetHashCache(crud.TableName, crud.CacheKey)
-	val, ok := getCacheRes.Value.(GetResultType)
-	if getCacheRes.Ok && ok && len(val.Records) > 0 {
+	if val, ok := crud.getCache(); ok {
 		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 			Message: "records successfully retrieved from the cache",
 			Value:   val,
@@ -281,6 +276,7 @@ func (crud Crud) GetByIds() mcresponse.ResponseMessage {
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
 		Limit: crud.Limit,
+		AppId: crud.TenantId(),
 	}
 	getQueryRes := ComputeSelectQueryByIds(crud.ModelRef, crud.TableName, crud.RecordIds, selectOptions)
 	if !getQueryRes.Ok {
@@ -292,8 +288,8 @@ func (crud Crud) GetByIds() mcresponse.ResponseMessage {
 	//fmt.Printf("Get-query-by-ids: %#v \n", getQueryRes )
 	// totalRecordsCount from the table
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	countQueryRes := ComputeSelectCountQuery(crud.TableName, selectOptions)
+	tRowErr := crud.AppDb.QueryRowx(countQueryRes.SelectQueryObject.SelectQuery, countQueryRes.SelectQueryObject.FieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -399,7 +395,7 @@ func (crud Crud) GetByIds() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, uint(crud.CacheExpire))
+	crud.setCache(getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
@@ -411,9 +407,7 @@ func (crud Crud) GetByIds() mcresponse.ResponseMessage {
 // constrained by optional skip and limit parameters
 func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
 	// check cache
-	getCacheRes := mccache.GetHashCache(crud.TableName, crud.CacheKey)
-	val, ok := getCacheRes.Value.(GetResultType)
-	if getCacheRes.Ok && ok && len(val.Records) > 0 {
+	if val, ok := crud.getCache(); ok {
 		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 			Message: "records successfully retrieved from the cache",
 			Value:   val,
@@ -423,6 +417,7 @@ func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
 		Limit: crud.Limit,
+		AppId: crud.TenantId(),
 	}
 	getQueryRes := ComputeSelectQueryByParam(crud.ModelRef, crud.TableName, crud.QueryParams, selectOptions)
 	if !getQueryRes.Ok {
@@ -434,8 +429,8 @@ func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
 	//fmt.Printf("Get-query-by-params: %#v \n\n", getQueryRes )
 	// totalRecordsCount from the table
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	countQueryRes := ComputeSelectCountQuery(crud.TableName, selectOptions)
+	tRowErr := crud.AppDb.QueryRowx(countQueryRes.SelectQueryObject.SelectQuery, countQueryRes.SelectQueryObject.FieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -539,7 +534,7 @@ func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, uint(crud.CacheExpire))
+	crud.setCache(getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
@@ -553,6 +548,7 @@ func (crud *Crud) GetAll() mcresponse.ResponseMessage {
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
 		Limit: crud.Limit,
+		AppId: crud.TenantId(),
 	}
 	getQueryRes := ComputeSelectQueryAll(crud.ModelRef, crud.TableName, selectOptions)
 	if !getQueryRes.Ok {
@@ -564,8 +560,8 @@ func (crud *Crud) GetAll() mcresponse.ResponseMessage {
 	//fmt.Printf("Get-query-by-all: %#v", getQueryRes )
 	// totalRecordsCount from the table
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	countQueryRes := ComputeSelectCountQuery(crud.TableName, selectOptions)
+	tRowErr := crud.AppDb.QueryRowx(countQueryRes.SelectQueryObject.SelectQuery, countQueryRes.SelectQueryObject.FieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -680,9 +676,7 @@ func (crud *Crud) GetAll() mcresponse.ResponseMessage {
 
 func (crud Crud) GetByIds1() mcresponse.ResponseMessage {
 	// check cache
-	getCacheRes := mccache.GetHashCache(crud.TableName, crud.CacheKey)
-	val, ok := getCacheRes.Value.(GetResultType)
-	if getCacheRes.Ok && ok && len(val.Records) > 0 {
+	if val, ok := crud.getCache(); ok {
 		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 			Message: "records successfully retrieved from the cache",
 			Value:   val,
@@ -699,6 +693,7 @@ func (crud Crud) GetByIds1() mcresponse.ResponseMessage {
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
 		Limit: crud.Limit,
+		AppId: crud.TenantId(),
 	}
 	getQueryRes := ComputeSelectQueryByIds(crud.ModelRef, crud.TableName, crud.RecordIds, selectOptions)
 	if !getQueryRes.Ok {
@@ -710,8 +705,8 @@ func (crud Crud) GetByIds1() mcresponse.ResponseMessage {
 	//fmt.Printf("Get-query-by-ids: %#v \n", getQueryRes )
 	// totalRecordsCount from the table
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	countQueryRes := ComputeSelectCountQuery(crud.TableName, selectOptions)
+	tRowErr := crud.AppDb.QueryRowx(countQueryRes.SelectQueryObject.SelectQuery, countQueryRes.SelectQueryObject.FieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -826,7 +821,7 @@ func (crud Crud) GetByIds1() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, uint(crud.CacheExpire))
+	crud.setCache(getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
@@ -838,9 +833,7 @@ func (crud Crud) GetByIds1() mcresponse.ResponseMessage {
 // constrained by optional skip and limit parameters
 func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
 	// check cache
-	getCacheRes := mccache.GetHashCache(crud.TableName, crud.CacheKey)
-	val, ok := getCacheRes.Value.(GetResultType)
-	if getCacheRes.Ok && ok && len(val.Records) > 0 {
+	if val, ok := crud.getCache(); ok {
 		return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 			Message: "records successfully retrieved from the cache",
 			Value:   val,
@@ -850,6 +843,7 @@ func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
 		Limit: crud.Limit,
+		AppId: crud.TenantId(),
 	}
 	getQueryRes := ComputeSelectQueryByParam(crud.ModelRef, crud.TableName, crud.QueryParams, selectOptions)
 	if !getQueryRes.Ok {
@@ -861,8 +855,8 @@ func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
 	//fmt.Printf("Get-query-by-params: %#v \n\n", getQueryRes )
 	// totalRecordsCount from the table
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	countQueryRes := ComputeSelectCountQuery(crud.TableName, selectOptions)
+	tRowErr := crud.AppDb.QueryRowx(countQueryRes.SelectQueryObject.SelectQuery, countQueryRes.SelectQueryObject.FieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -976,7 +970,7 @@ func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, uint(crud.CacheExpire))
+	crud.setCache(getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
@@ -990,6 +984,7 @@ func (crud *Crud) GetAll1() mcresponse.ResponseMessage {
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
 		Limit: crud.Limit,
+		AppId: crud.TenantId(),
 	}
 	getQueryRes := ComputeSelectQueryAll(crud.ModelRef, crud.TableName, selectOptions)
 	if !getQueryRes.Ok {
@@ -1001,8 +996,8 @@ func (crud *Crud) GetAll1() mcresponse.ResponseMessage {
 	//fmt.Printf("Get-query-by-all: %#v", getQueryRes )
 	// totalRecordsCount from the table
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	countQueryRes := ComputeSelectCountQuery(crud.TableName, selectOptions)
+	tRowErr := crud.AppDb.QueryRowx(countQueryRes.SelectQueryObject.SelectQuery, countQueryRes.SelectQueryObject.FieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
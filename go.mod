@@ -3,11 +3,11 @@ module github.com/abbeymart/mccrud
 go 1.15
 
 require (
-	github.com/abbeymart/mccache v0.3.1
 	github.com/abbeymart/mcresponse v0.6.0
 	github.com/abbeymart/mctest v0.5.3
 	github.com/asaskevich/govalidator v0.0.0-20200907205600-7a23bdc65eef
 	github.com/jmoiron/sqlx v1.3.4
 	github.com/lib/pq v1.10.3
 	github.com/mattn/go-sqlite3 v1.14.8
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 )
@@ -0,0 +1,61 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: mail delivery, for user-verification and password-reset messages
+
+package mccrud
+
+import (
+	"sync"
+)
+
+// MailMessageType is the mail message for delivery
+type MailMessageType struct {
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+	Token   string `json:"-"` // verification/reset token included in the Body, for custom mail templates
+}
+
+// Mailer delivers mail messages, e.g. via SMTP or a mail-service API
+type Mailer interface {
+	SendMail(message MailMessageType) error
+}
+
+// MemoryMailer stores the mail messages in memory, for tests
+type MemoryMailer struct {
+	mutex    sync.Mutex
+	Messages []MailMessageType
+}
+
+// NewMemoryMailer constructor returns a new MemoryMailer instance
+func NewMemoryMailer() *MemoryMailer {
+	return &MemoryMailer{}
+}
+
+// SendMail method stores the mail message
+func (mailer *MemoryMailer) SendMail(message MailMessageType) error {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+	mailer.Messages = append(mailer.Messages, message)
+	return nil
+}
+
+// LastMessage method returns the last mail message sent to the recipient
+func (mailer *MemoryMailer) LastMessage(to string) (MailMessageType, bool) {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+	for i := len(mailer.Messages) - 1; i >= 0; i-- {
+		if mailer.Messages[i].To == to {
+			return mailer.Messages[i], true
+		}
+	}
+	return MailMessageType{}, false
+}
+
+// Reset method removes all the stored mail messages
+func (mailer *MemoryMailer) Reset() {
+	mailer.mutex.Lock()
+	defer mailer.mutex.Unlock()
+	mailer.Messages = nil
+}
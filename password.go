@@ -0,0 +1,87 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: password hashing and secure token helpers
+
+package mccrud
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"golang.org/x/crypto/bcrypt"
+	"sync"
+)
+
+// PasswordHasher hashes and verifies user passwords
+type PasswordHasher interface {
+	HashPassword(password string) (string, error)
+	ComparePassword(hashedPassword string, password string) error
+}
+
+// BcryptPasswordHasher is the bcrypt PasswordHasher
+type BcryptPasswordHasher struct {
+	Cost int
+}
+
+// NewBcryptPasswordHasher constructor returns a new BcryptPasswordHasher instance, default cost: bcrypt.DefaultCost
+func NewBcryptPasswordHasher(cost int) *BcryptPasswordHasher {
+	if cost < bcrypt.MinCost {
+		cost = bcrypt.DefaultCost
+	}
+	return &BcryptPasswordHasher{Cost: cost}
+}
+
+// HashPassword method returns the bcrypt-hash of the password
+func (hasher *BcryptPasswordHasher) HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), hasher.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// ComparePassword method returns nil if the password matches the bcrypt-hashedPassword
+func (hasher *BcryptPasswordHasher) ComparePassword(hashedPassword string, password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+}
+
+// dummyPasswordHashes is the hash of the dummy password, by password hasher
+var dummyPasswordHashes sync.Map
+
+// dummyPasswordHash returns the (cached) hash of a random dummy password, of the hasher. The logins of the unknown
+// users compare the password with the dummy hash, so that the login response time does not reveal the accounts.
+func dummyPasswordHash(hasher PasswordHasher) string {
+	hasherKey := fmt.Sprintf("%T|%#v", hasher, hasher)
+	if hash, ok := dummyPasswordHashes.Load(hasherKey); ok {
+		return hash.(string)
+	}
+	dummyPassword, err := GenerateToken(16)
+	if err != nil {
+		dummyPassword = hasherKey
+	}
+	hash, err := hasher.HashPassword(dummyPassword)
+	if err != nil {
+		return ""
+	}
+	actualHash, _ := dummyPasswordHashes.LoadOrStore(hasherKey, hash)
+	return actualHash.(string)
+}
+
+// GenerateToken function returns a secure random (hex-encoded) token of byteLength random bytes, default: 32
+func GenerateToken(byteLength int) (string, error) {
+	if byteLength <= 0 {
+		byteLength = 32
+	}
+	tokenBytes := make([]byte, byteLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+// HashToken function returns the (hex-encoded) sha256-hash of the token, for token-storage
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,650 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: attribute-based access policies, evaluated before and after the role-services access check
+
+package mccrud
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/asaskevich/govalidator"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// policy effects and stages
+const (
+	PolicyAllow       = "allow"
+	PolicyDeny        = "deny"
+	PolicyBeforeStage = "before" // before the role check: user, task, table, environment and the action-params (create/update) records
+	PolicyAfterStage  = "after"  // after the role check: the current (update/delete) or returned (read) records
+)
+
+// PolicyConditionType is the attribute condition of a policy rule.
+// Attributes: user.<field>, profile.<field>, record.<field>, task, table, env.now, env.hour, env.weekday, env.date, env.time
+// Operators: eq, ne, lt, lte, gt, gte, in, nin, contains, exists
+type PolicyConditionType struct {
+	Attribute      string      `json:"attribute"`
+	Operator       string      `json:"operator"`
+	Value          interface{} `json:"value"`
+	ValueAttribute string      `json:"valueAttribute"` // compare with another attribute value, e.g. user.userId
+}
+
+// PolicyRuleType is the declarative policy rule, all conditions must match for the rule to apply
+type PolicyRuleType struct {
+	Id          string                `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Effect      string                `json:"effect"` // allow | deny
+	Tables      []string              `json:"tables"` // applicable tables, all tables if empty
+	Tasks       []string              `json:"tasks"`  // applicable tasks (create, update, delete, read), all tasks if empty
+	Stage       string                `json:"stage"`  // before | after, default: before
+	Conditions  []PolicyConditionType `json:"conditions"`
+	Priority    int                   `json:"priority"` // higher priority rules are evaluated first
+	IsActive    bool                  `json:"isActive"`
+}
+
+// PolicyContextType is the attributes context for policies evaluation
+type PolicyContextType struct {
+	Stage   string                 `json:"stage"`
+	Task    string                 `json:"task"`
+	Table   string                 `json:"table"`
+	User    map[string]interface{} `json:"user"`
+	Profile map[string]interface{} `json:"profile"`
+	Record  map[string]interface{} `json:"record"`
+	Env     map[string]interface{} `json:"env"` // default: computed from the current time
+}
+
+// PolicyRuleResultType is the evaluation result of a policy rule
+type PolicyRuleResultType struct {
+	RuleId  string `json:"ruleId"`
+	Name    string `json:"name"`
+	Effect  string `json:"effect"`
+	Matched bool   `json:"matched"`
+	Reason  string `json:"reason"`
+}
+
+// PolicyDecisionType is the policies evaluation decision
+type PolicyDecisionType struct {
+	Allowed bool                   `json:"allowed"`
+	Message string                 `json:"message"`
+	RuleId  string                 `json:"ruleId"` // deciding rule, if any
+	Rules   []PolicyRuleResultType `json:"rules"`  // evaluation trace of the applicable rules
+}
+
+// LoadPoliciesFromJson function decodes the json-array of policy rules
+func LoadPoliciesFromJson(jsonStr string) ([]PolicyRuleType, error) {
+	var policies []PolicyRuleType
+	if err := json.Unmarshal([]byte(jsonStr), &policies); err != nil {
+		return nil, errors.New(fmt.Sprintf("error parsing policies: %v", err.Error()))
+	}
+	for _, policy := range policies {
+		if policy.Effect != PolicyAllow && policy.Effect != PolicyDeny {
+			return nil, errors.New(fmt.Sprintf("policy %v: effect must be allow or deny", policy.Name))
+		}
+	}
+	return policies, nil
+}
+
+// PolicyEnv function returns the environment attributes of the time
+func PolicyEnv(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"now":     now,
+		"hour":    float64(now.Hour()),
+		"weekday": float64(now.Weekday()),
+		"date":    now.Format("2006-01-02"),
+		"time":    now.Format("15:04"),
+	}
+}
+
+// policyAttribute returns the attribute value from the policy context
+func policyAttribute(ctx PolicyContextType, attribute string) (interface{}, bool) {
+	parts := strings.SplitN(attribute, ".", 2)
+	var source map[string]interface{}
+	switch parts[0] {
+	case "task":
+		return ctx.Task, true
+	case "table":
+		return ctx.Table, true
+	case "user":
+		source = ctx.User
+	case "profile":
+		source = ctx.Profile
+	case "record":
+		source = ctx.Record
+	case "env":
+		source = ctx.Env
+	default:
+		return nil, false
+	}
+	if len(parts) < 2 || source == nil {
+		return nil, false
+	}
+	// field-name lookup: as specified, underscore or camelCase
+	fieldName := parts[1]
+	if val, ok := source[fieldName]; ok {
+		return val, true
+	}
+	if val, ok := source[govalidator.CamelCaseToUnderscore(fieldName)]; ok {
+		return val, true
+	}
+	if val, ok := source[ToCamelCase(fieldName, "_")]; ok {
+		return val, true
+	}
+	return nil, false
+}
+
+// policyNumber returns the float64 value of numeric values
+func policyNumber(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case json.Number:
+		num, err := v.Float64()
+		return num, err == nil
+	case string, bool, nil:
+		return 0, false
+	}
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// policyCompare returns -1, 0 or 1 for comparable (number, time or string) values
+func policyCompare(a interface{}, b interface{}) (int, bool) {
+	if aNum, ok := policyNumber(a); ok {
+		if bNum, ok := policyNumber(b); ok {
+			switch {
+			case aNum < bNum:
+				return -1, true
+			case aNum > bNum:
+				return 1, true
+			}
+			return 0, true
+		}
+		return 0, false
+	}
+	if aTime, ok := a.(time.Time); ok {
+		bTime, ok := b.(time.Time)
+		if !ok {
+			if bStr, strOk := b.(string); strOk {
+				parsed, err := time.Parse(time.RFC3339, bStr)
+				if err != nil {
+					return 0, false
+				}
+				bTime = parsed
+			} else {
+				return 0, false
+			}
+		}
+		switch {
+		case aTime.Before(bTime):
+			return -1, true
+		case aTime.After(bTime):
+			return 1, true
+		}
+		return 0, true
+	}
+	aStr, aOk := a.(string)
+	bStr, bOk := b.(string)
+	if aOk && bOk {
+		return strings.Compare(aStr, bStr), true
+	}
+	return 0, false
+}
+
+// policyEqual returns true for equal values, numbers are compared by value
+func policyEqual(a interface{}, b interface{}) bool {
+	if cmp, ok := policyCompare(a, b); ok {
+		return cmp == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// policyItems returns the items of slice values
+func policyItems(val interface{}) ([]interface{}, bool) {
+	if val == nil {
+		return nil, false
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, false
+	}
+	items := make([]interface{}, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		items[i] = rv.Index(i).Interface()
+	}
+	return items, true
+}
+
+// EvaluatePolicyCondition function returns true if the policy condition matches the context
+func EvaluatePolicyCondition(condition PolicyConditionType, ctx PolicyContextType) bool {
+	attrValue, attrOk := policyAttribute(ctx, condition.Attribute)
+	if condition.Operator == "exists" {
+		expected := true
+		if val, ok := condition.Value.(bool); ok {
+			expected = val
+		}
+		return (attrOk && attrValue != nil) == expected
+	}
+	if !attrOk {
+		return false
+	}
+	value := condition.Value
+	if condition.ValueAttribute != "" {
+		val, ok := policyAttribute(ctx, condition.ValueAttribute)
+		if !ok {
+			return false
+		}
+		value = val
+	}
+	switch condition.Operator {
+	case "eq", "":
+		return policyEqual(attrValue, value)
+	case "ne":
+		return !policyEqual(attrValue, value)
+	case "lt", "lte", "gt", "gte":
+		cmp, ok := policyCompare(attrValue, value)
+		if !ok {
+			return false
+		}
+		switch condition.Operator {
+		case "lt":
+			return cmp < 0
+		case "lte":
+			return cmp <= 0
+		case "gt":
+			return cmp > 0
+		}
+		return cmp >= 0
+	case "in", "nin":
+		items, ok := policyItems(value)
+		if !ok {
+			return false
+		}
+		found := false
+		for _, item := range items {
+			if policyEqual(attrValue, item) {
+				found = true
+				break
+			}
+		}
+		return found == (condition.Operator == "in")
+	case "contains":
+		if attrStr, ok := attrValue.(string); ok {
+			valStr, valOk := value.(string)
+			return valOk && strings.Contains(attrStr, valStr)
+		}
+		items, ok := policyItems(attrValue)
+		if !ok {
+			return false
+		}
+		for _, item := range items {
+			if policyEqual(item, value) {
+				return true
+			}
+		}
+		return false
+	}
+	return false
+}
+
+// policyApplicable returns true if the active policy rule applies to the context stage, table and task
+func policyApplicable(policy PolicyRuleType, ctx PolicyContextType) bool {
+	stage := policy.Stage
+	if stage == "" {
+		stage = PolicyBeforeStage
+	}
+	if !policy.IsActive || stage != ctx.Stage {
+		return false
+	}
+	if len(policy.Tables) > 0 && !ArrayStringContains(policy.Tables, ctx.Table) {
+		return false
+	}
+	if len(policy.Tasks) > 0 && !ArrayStringContains(policy.Tasks, ctx.Task) {
+		return false
+	}
+	return true
+}
+
+// EvaluatePolicies function evaluates the policies applicable to the context, with deny-overrides-allow:
+// a matching deny rule denies; if allow rules apply, at least one must match; no applicable rules permits.
+func EvaluatePolicies(policies []PolicyRuleType, ctx PolicyContextType) PolicyDecisionType {
+	if ctx.Env == nil {
+		ctx.Env = PolicyEnv(time.Now())
+	}
+	var applicable []PolicyRuleType
+	for _, policy := range policies {
+		if policyApplicable(policy, ctx) {
+			applicable = append(applicable, policy)
+		}
+	}
+	sort.SliceStable(applicable, func(i, j int) bool {
+		return applicable[i].Priority > applicable[j].Priority
+	})
+	decision := PolicyDecisionType{Allowed: true, Message: "No applicable policy"}
+	allowRules := 0
+	allowMatched := ""
+	for _, policy := range applicable {
+		result := PolicyRuleResultType{RuleId: policy.Id, Name: policy.Name, Effect: policy.Effect, Matched: true}
+		for _, condition := range policy.Conditions {
+			if !EvaluatePolicyCondition(condition, ctx) {
+				result.Matched = false
+				result.Reason = fmt.Sprintf("condition not met: %v %v %v%v", condition.Attribute, condition.Operator, condition.Value, condition.ValueAttribute)
+				break
+			}
+		}
+		decision.Rules = append(decision.Rules, result)
+		if policy.Effect == PolicyDeny {
+			if result.Matched && decision.Allowed {
+				decision.Allowed = false
+				decision.RuleId = policy.Id
+				decision.Message = fmt.Sprintf("Denied by policy: %v", policy.Name)
+			}
+			continue
+		}
+		allowRules += 1
+		if result.Matched && allowMatched == "" {
+			allowMatched = policy.Id
+			if decision.Allowed {
+				decision.RuleId = policy.Id
+				decision.Message = fmt.Sprintf("Permitted by policy: %v", policy.Name)
+			}
+		}
+	}
+	if decision.Allowed && allowRules > 0 && allowMatched == "" {
+		decision.Allowed = false
+		decision.Message = "No allow policy matched"
+	}
+	return decision
+}
+
+// DryRunPolicies function evaluates the policies for the context, without performing any task; for testing policies
+func DryRunPolicies(policies []PolicyRuleType, ctx PolicyContextType) PolicyDecisionType {
+	if ctx.Stage == "" {
+		ctx.Stage = PolicyBeforeStage
+	}
+	return EvaluatePolicies(policies, ctx)
+}
+
+// GetPolicies method returns the crud Policies, or the active policies from the policy-table
+func (crud *Crud) GetPolicies() ([]PolicyRuleType, error) {
+	if crud.Policies != nil {
+		return crud.Policies, nil
+	}
+	policyScript := fmt.Sprintf("SELECT id, name, description, effect, tables, tasks, stage, conditions, priority from %v WHERE is_active=$1", crud.PolicyTable)
+	rows, err := crud.AccessDb.Queryx(policyScript, true)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	var policies []PolicyRuleType
+	for rows.Next() {
+		var (
+			policy                       PolicyRuleType
+			description, stage           sql.NullString
+			tables, tasks, conditionsVal sql.NullString
+		)
+		if err := rows.Scan(&policy.Id, &policy.Name, &description, &policy.Effect, &tables, &tasks, &stage, &conditionsVal, &policy.Priority); err != nil {
+			return nil, err
+		}
+		policy.Description = description.String
+		policy.Stage = stage.String
+		policy.IsActive = true
+		if tables.Valid && tables.String != "" {
+			if err := json.Unmarshal([]byte(tables.String), &policy.Tables); err != nil {
+				return nil, errors.New(fmt.Sprintf("policy %v: error parsing tables: %v", policy.Name, err.Error()))
+			}
+		}
+		if tasks.Valid && tasks.String != "" {
+			if err := json.Unmarshal([]byte(tasks.String), &policy.Tasks); err != nil {
+				return nil, errors.New(fmt.Sprintf("policy %v: error parsing tasks: %v", policy.Name, err.Error()))
+			}
+		}
+		if conditionsVal.Valid && conditionsVal.String != "" {
+			if err := json.Unmarshal([]byte(conditionsVal.String), &policy.Conditions); err != nil {
+				return nil, errors.New(fmt.Sprintf("policy %v: error parsing conditions: %v", policy.Name, err.Error()))
+			}
+		}
+		policies = append(policies, policy)
+	}
+	crud.Policies = policies
+	return policies, nil
+}
+
+// PolicyContext method computes the policy context of the current user, for the stage and task
+func (crud *Crud) PolicyContext(stage string, taskType string) (PolicyContextType, error) {
+	if crud.AccessInfo.UserId == "" {
+		accessRes := crud.CheckUserAccess()
+		if accessRes.Code != "success" {
+			return PolicyContextType{}, errors.New(accessRes.Message)
+		}
+		accessInfo, ok := accessRes.Value.(AccessInfoType)
+		if !ok {
+			return PolicyContextType{}, errors.New("error parsing user access information/value")
+		}
+		crud.AccessInfo = accessInfo
+	}
+	user, _ := StructToMap(crud.UserInfo)
+	if user == nil {
+		user = map[string]interface{}{}
+	}
+	user["userId"] = crud.AccessInfo.UserId
+	user["roleId"] = crud.AccessInfo.RoleId
+	user["roleIds"] = crud.AccessInfo.RoleIds
+	user["isAdmin"] = crud.AccessInfo.IsAdmin
+	user["isActive"] = crud.AccessInfo.IsActive
+	profile, _ := StructToMap(crud.AccessInfo.Profile)
+	return PolicyContextType{
+		Stage:   stage,
+		Task:    taskType,
+		Table:   crud.TableName,
+		User:    user,
+		Profile: profile,
+		Env:     PolicyEnv(time.Now()),
+	}, nil
+}
+
+// CheckPolicy method evaluates the stage policies for the task and the records; all the records must be permitted
+func (crud *Crud) CheckPolicy(stage string, taskType string, records []map[string]interface{}) mcresponse.ResponseMessage {
+	policies, err := crud.GetPolicies()
+	if err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error loading access policies: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	ctx, err := crud.PolicyContext(stage, taskType)
+	if err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if len(records) < 1 {
+		records = []map[string]interface{}{nil}
+	}
+	var decision PolicyDecisionType
+	for _, rec := range records {
+		ctx.Record = rec
+		decision = EvaluatePolicies(policies, ctx)
+		if !decision.Allowed {
+			crud.RecordAccessDecision(AccessDecisionType{
+				TaskType: taskType,
+				UserId:   crud.AccessInfo.UserId,
+				RoleId:   crud.AccessInfo.RoleId,
+				RoleIds:  crud.AccessInfo.RoleIds,
+				IsAdmin:  crud.AccessInfo.IsAdmin,
+				IsActive: crud.AccessInfo.IsActive,
+				Rule:     AccessRulePolicy,
+				RuleId:   decision.RuleId,
+				Reason:   decision.Message,
+			})
+			return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+				Message: decision.Message,
+				Value:   decision,
+			})
+		}
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: decision.Message,
+		Value:   decision,
+	})
+}
+
+// policyCurrentRecords returns the current records of the update/delete task, for the after-stage policies
+func (crud *Crud) policyCurrentRecords() ([]map[string]interface{}, mcresponse.ResponseMessage) {
+	var getRes mcresponse.ResponseMessage
+	if len(crud.RecordIds) > 0 {
+		getRes = crud.GetByIds()
+	} else if len(crud.QueryParams) > 0 {
+		getRes = crud.GetByParam()
+	} else {
+		return nil, mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{})
+	}
+	if getRes.Code != "success" {
+		return nil, getRes
+	}
+	result, _ := getRes.Value.(GetResultType)
+	return result.Records, getRes
+}
+
+// PageRecords function returns the records page of the skip and limit (0: all the records after skip)
+func PageRecords(records []map[string]interface{}, skip int, limit int) []map[string]interface{} {
+	if skip < 0 {
+		skip = 0
+	}
+	if skip >= len(records) {
+		return nil
+	}
+	records = records[skip:]
+	if limit > 0 && limit < len(records) {
+		records = records[:limit]
+	}
+	return records
+}
+
+// PolicyReadAccess method performs the read-task (getFunc) and removes the records denied by the after-stage
+// policies. The policy read is unpaged (up to MaxQueryLimit), the permitted records are paged by the crud Skip and
+// Limit, and the TotalRecordsCount is the permitted records count.
+func (crud *Crud) PolicyReadAccess(getFunc func() mcresponse.ResponseMessage) mcresponse.ResponseMessage {
+	if !crud.PolicyCheck {
+		return getFunc()
+	}
+	skip, limit, cacheKey := crud.Skip, crud.Limit, crud.CacheKey
+	crud.Skip, crud.Limit = 0, crud.MaxQueryLimit
+	crud.CacheKey = fmt.Sprintf("%v-policy-%v", cacheKey, crud.MaxQueryLimit)
+	res := getFunc()
+	crud.Skip, crud.Limit, crud.CacheKey = skip, limit, cacheKey
+	if res.Code != "success" {
+		return res
+	}
+	result, ok := res.Value.(GetResultType)
+	if !ok {
+		return res
+	}
+	policies, err := crud.GetPolicies()
+	if err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error loading access policies: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	ctx, err := crud.PolicyContext(PolicyAfterStage, ReadTask)
+	if err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	var permittedRecords []map[string]interface{}
+	for _, rec := range result.Records {
+		ctx.Record = rec
+		if EvaluatePolicies(policies, ctx).Allowed {
+			permittedRecords = append(permittedRecords, rec)
+		}
+	}
+	result.Records = PageRecords(permittedRecords, skip, limit)
+	result.Stats.Skip = skip
+	result.Stats.Limit = limit
+	result.Stats.RecordsCount = len(result.Records)
+	result.Stats.TotalRecordsCount = len(permittedRecords)
+	res.Value = result
+	return res
+}
+
+// TaskAccess method performs the before-stage policies, the role/task permission (accessFunc, if CheckAccess),
+// the field-level write permission (create/update) and the after-stage (create/update/delete) policies checks
+func (crud *Crud) TaskAccess(taskType string, accessFunc func() mcresponse.ResponseMessage) mcresponse.ResponseMessage {
+	saveTask := taskType == CreateTask || taskType == UpdateTask
+	if crud.PolicyCheck {
+		var records []map[string]interface{}
+		if saveTask {
+			for _, rec := range crud.ActionParams {
+				records = append(records, map[string]interface{}(rec))
+			}
+		}
+		if policyRes := crud.CheckPolicy(PolicyBeforeStage, taskType, records); policyRes.Code != "success" {
+			return policyRes
+		}
+	}
+	accessRes := mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Action authorised / permitted.",
+		Value:   nil,
+	})
+	if crud.CheckAccess {
+		accessRes = accessFunc()
+		if accessRes.Code != "success" {
+			return accessRes
+		}
+		if saveTask {
+			if fieldRes := crud.CheckFieldWriteAccess(); fieldRes.Code != "success" {
+				decision := crud.AccessDecision
+				decision.Allowed = false
+				decision.TaskType = taskType
+				decision.Rule = AccessRuleFieldWrite
+				decision.Reason = fieldRes.Message
+				crud.RecordAccessDecision(decision)
+				return fieldRes
+			}
+		}
+		if taskType == ReadTask {
+			if fieldRes := crud.CheckFieldReadAccess(); fieldRes.Code != "success" {
+				decision := crud.AccessDecision
+				decision.Allowed = false
+				decision.TaskType = taskType
+				decision.Rule = AccessRuleFieldRead
+				decision.Reason = fieldRes.Message
+				crud.RecordAccessDecision(decision)
+				return fieldRes
+			}
+		}
+	}
+	if crud.PolicyCheck && taskType != ReadTask {
+		records, getRes := crud.policyCurrentRecords()
+		if getRes.Code != "success" && getRes.Code != "notFound" {
+			return getRes
+		}
+		if taskType == CreateTask {
+			for _, rec := range crud.ActionParams {
+				records = append(records, map[string]interface{}(rec))
+			}
+		}
+		if policyRes := crud.CheckPolicy(PolicyAfterStage, taskType, records); policyRes.Code != "success" {
+			return policyRes
+		}
+	}
+	return accessRes
+}
@@ -0,0 +1,159 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: attribute-based access policies test cases
+
+package mccrud
+
+import (
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestPolicy(t *testing.T) {
+	policies, loadErr := LoadPoliciesFromJson(`[
+		{"id": "p1", "name": "managers update small orders in their region", "effect": "allow", "tables": ["orders"], "tasks": ["update"], "stage": "after", "isActive": true,
+		 "conditions": [
+			{"attribute": "user.roleIds", "operator": "contains", "value": "manager"},
+			{"attribute": "record.amount", "operator": "lt", "value": 10000},
+			{"attribute": "record.region", "operator": "eq", "valueAttribute": "profile.region"},
+			{"attribute": "env.hour", "operator": "gte", "value": 8},
+			{"attribute": "env.hour", "operator": "lt", "value": 17}
+		 ]},
+		{"id": "p2", "name": "no updates of closed orders", "effect": "deny", "tables": ["orders"], "stage": "after", "priority": 10, "isActive": true,
+		 "conditions": [{"attribute": "record.status", "operator": "in", "value": ["closed", "archived"]}]}
+	]`)
+	businessHours := PolicyEnv(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	ctx := PolicyContextType{
+		Stage:   PolicyAfterStage,
+		Task:    UpdateTask,
+		Table:   "orders",
+		User:    map[string]interface{}{"userId": "user-1", "roleIds": []string{"staff", "manager"}},
+		Profile: map[string]interface{}{"region": "west"},
+		Record:  map[string]interface{}{"amount": 2500, "region": "west", "status": "open"},
+		Env:     businessHours,
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should load policies from json:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, loadErr, nil, "no load error expected")
+			mctest.AssertEquals(t, len(policies), 2, "two policies expected")
+			_, err := LoadPoliciesFromJson(`[{"name": "invalid", "effect": "maybe"}]`)
+			mctest.AssertEquals(t, err != nil, true, "invalid effect error expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should permit by matching allow policy:",
+		TestFunc: func() {
+			decision := DryRunPolicies(policies, ctx)
+			mctest.AssertEquals(t, decision.Allowed, true, "update should be permitted")
+			mctest.AssertEquals(t, decision.RuleId, "p1", "allow policy p1 expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should deny if no allow policy matched:",
+		TestFunc: func() {
+			largeOrder := ctx
+			largeOrder.Record = map[string]interface{}{"amount": 25000, "region": "west", "status": "open"}
+			mctest.AssertEquals(t, DryRunPolicies(policies, largeOrder).Allowed, false, "large order update should be denied")
+			otherRegion := ctx
+			otherRegion.Record = map[string]interface{}{"amount": 2500, "region": "east", "status": "open"}
+			mctest.AssertEquals(t, DryRunPolicies(policies, otherRegion).Allowed, false, "other region update should be denied")
+			afterHours := ctx
+			afterHours.Env = PolicyEnv(time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC))
+			mctest.AssertEquals(t, DryRunPolicies(policies, afterHours).Allowed, false, "after-hours update should be denied")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should deny by matching deny policy, overriding allow policy:",
+		TestFunc: func() {
+			closedOrder := ctx
+			closedOrder.Record = map[string]interface{}{"amount": 2500, "region": "west", "status": "closed"}
+			decision := DryRunPolicies(policies, closedOrder)
+			mctest.AssertEquals(t, decision.Allowed, false, "closed order update should be denied")
+			mctest.AssertEquals(t, decision.RuleId, "p2", "deny policy p2 expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should permit if no policy applies:",
+		TestFunc: func() {
+			other := ctx
+			other.Table = "products"
+			mctest.AssertEquals(t, DryRunPolicies(policies, other).Allowed, true, "no applicable policy, task should be permitted")
+			before := ctx
+			before.Stage = PolicyBeforeStage
+			mctest.AssertEquals(t, DryRunPolicies(policies, before).Allowed, true, "no before-stage policy, task should be permitted")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should apply the read policies before paging, and count the permitted records:",
+		TestFunc: func() {
+			crud := Crud{
+				CrudParamsType:  CrudParamsType{TableName: "orders", TaskType: ReadTask, Skip: 1, Limit: 2},
+				CrudOptionsType: CrudOptionsType{PolicyCheck: true, Policies: policies, MaxQueryLimit: 1000},
+				AccessInfo:      AccessInfoType{UserId: "user-1"},
+			}
+			crud.CacheKey = "orders-paged"
+			var readSkip, readLimit int
+			var readCacheKey string
+			res := crud.PolicyReadAccess(func() mcresponse.ResponseMessage {
+				readSkip, readLimit, readCacheKey = crud.Skip, crud.Limit, crud.CacheKey
+				var records []map[string]interface{}
+				for _, status := range []string{"closed", "open", "archived", "open", "open"} {
+					records = append(records, map[string]interface{}{"id": len(records) + 1, "status": status})
+				}
+				return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+					Value: GetResultType{Records: records, Stats: GetStatType{RecordsCount: len(records), TotalRecordsCount: len(records)}},
+				})
+			})
+			mctest.AssertEquals(t, readSkip, 0, "unpaged policy read expected")
+			mctest.AssertEquals(t, readLimit, 1000, "policy read, up to the max query limit, expected")
+			mctest.AssertNotEquals(t, readCacheKey, "orders-paged", "unpaged policy read cache key expected")
+			mctest.AssertEquals(t, crud.Skip, 1, "restored skip expected")
+			mctest.AssertEquals(t, crud.CacheKey, "orders-paged", "restored cache key expected")
+			mctest.AssertEquals(t, res.Code, "success", "policy read should succeed")
+			result := res.Value.(GetResultType)
+			mctest.AssertEquals(t, len(result.Records), 2, "permitted records page expected")
+			mctest.AssertEquals(t, result.Records[0]["id"], 4, "second permitted record, after skip, expected")
+			mctest.AssertEquals(t, result.Records[1]["id"], 5, "third permitted record expected")
+			mctest.AssertEquals(t, result.Stats.RecordsCount, 2, "page records count expected")
+			mctest.AssertEquals(t, result.Stats.TotalRecordsCount, 3, "permitted records total expected")
+			mctest.AssertEquals(t, len(PageRecords(result.Records, 5, 2)), 0, "empty page, after the records, expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should apply the read policies before paging, of the multi-id reads:",
+		TestFunc: func() {
+			crud := Crud{
+				CrudParamsType:  CrudParamsType{TableName: "orders", TaskType: ReadTask, RecordIds: []string{"a", "b", "c"}, Limit: 1},
+				CrudOptionsType: CrudOptionsType{PolicyCheck: true, Policies: policies, MaxQueryLimit: 1000, Cache: NewLRUCache(10)},
+				AccessInfo:      AccessInfoType{UserId: "user-1"},
+			}
+			crud.CacheKey = "orders-ids-paged"
+			// the unpaged (policy) read result, of the cache
+			crud.CacheKey = fmt.Sprintf("%v-policy-%v", "orders-ids-paged", crud.MaxQueryLimit)
+			crud.setCache(GetResultType{Records: []map[string]interface{}{
+				{"id": "a", "status": "closed"}, {"id": "b", "status": "open"}, {"id": "c", "status": "open"},
+			}})
+			crud.CacheKey = "orders-ids-paged"
+			crud.Skip = 1
+			res := crud.GetRecord()
+			mctest.AssertEquals(t, res.Code, "success", "multi-id policy read should succeed")
+			result := res.Value.(GetResultType)
+			mctest.AssertEquals(t, len(result.Records), 1, "permitted records page expected")
+			mctest.AssertEquals(t, result.Records[0]["id"], "c", "second permitted record, after skip, expected")
+			mctest.AssertEquals(t, result.Stats.TotalRecordsCount, 2, "permitted records total expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,238 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: record history (versions) and point-in-time reconstruction, from the create, update and delete
+// audit-log entries
+
+package mccrud
+
+import (
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/asaskevich/govalidator"
+	"time"
+)
+
+// RecordVersionType is the record state after the create or update audit-log entry, or before the delete entry
+type RecordVersionType struct {
+	Version int                    `json:"version"`
+	AuditId string                 `json:"auditId"`
+	LogType string                 `json:"logType"`
+	LogBy   string                 `json:"logBy"`
+	LogAt   time.Time              `json:"logAt"`
+	Record  map[string]interface{} `json:"record"`
+	Deleted bool                   `json:"deleted"`
+}
+
+// auditPayloadField returns the field value of the decoded audit-log payload (json object)
+func auditPayloadField(payload interface{}, field string) interface{} {
+	if payloadMap, ok := payload.(map[string]interface{}); ok {
+		return payloadMap[field]
+	}
+	return nil
+}
+
+// auditPayloadRecord returns the record, by id, of the decoded audit-log records (json array)
+func auditPayloadRecord(records interface{}, recordId string) map[string]interface{} {
+	recs, ok := records.([]interface{})
+	if !ok {
+		return nil
+	}
+	for _, rec := range recs {
+		if recMap, ok := rec.(map[string]interface{}); ok && fmt.Sprintf("%v", recMap["id"]) == recordId {
+			return recMap
+		}
+	}
+	return nil
+}
+
+// auditCreatedRecord returns the created record, by id, of the create audit-log payload. The created records are
+// matched to the recordIds by position.
+func auditCreatedRecord(payload interface{}, recordId string) map[string]interface{} {
+	records := auditPayloadField(payload, "logRecords")
+	if rec := auditPayloadRecord(records, recordId); rec != nil {
+		return rec
+	}
+	recs, _ := records.([]interface{})
+	recordIds, _ := auditPayloadField(payload, "recordIds").([]interface{})
+	for i, id := range recordIds {
+		if fmt.Sprintf("%v", id) == recordId && i < len(recs) {
+			if rec, ok := recs[i].(map[string]interface{}); ok {
+				return rec
+			}
+		}
+	}
+	return nil
+}
+
+// auditUpdateRecord returns the update fields, by id, of the update audit-log (new records) payload. The single
+// update record, without id, applies to all the updated records.
+func auditUpdateRecord(payload interface{}, recordId string) map[string]interface{} {
+	records := auditPayloadField(payload, "logRecords")
+	if rec := auditPayloadRecord(records, recordId); rec != nil {
+		return rec
+	}
+	if recs, ok := records.([]interface{}); ok && len(recs) == 1 {
+		if rec, ok := recs[0].(map[string]interface{}); ok && rec["id"] == nil {
+			return rec
+		}
+	}
+	return nil
+}
+
+// copyRecord returns a copy of the record
+func copyRecord(record map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for key, val := range record {
+		result[key] = val
+	}
+	return result
+}
+
+// mergeRecord sets the update fields of the record, matched as camelCase or underscore field names
+func mergeRecord(record map[string]interface{}, updateRecord map[string]interface{}) {
+	recordFields := map[string]string{}
+	for fieldName := range record {
+		recordFields[govalidator.CamelCaseToUnderscore(fieldName)] = fieldName
+	}
+	for fieldName, val := range updateRecord {
+		if recordField, ok := recordFields[govalidator.CamelCaseToUnderscore(fieldName)]; ok {
+			record[recordField] = val
+			continue
+		}
+		record[fieldName] = val
+	}
+}
+
+// BuildRecordHistory function returns the ordered versions of the record, from the audit-log records (ascending by
+// log time). The update and delete entries prior-state records take precedence over the reconstructed state,
+// e.g. for the records created before the audit-log.
+func BuildRecordHistory(recordId string, auditRecords []AuditRecordType) []RecordVersionType {
+	var versions []RecordVersionType
+	var state map[string]interface{}
+	for _, auditRecord := range auditRecords {
+		version := RecordVersionType{
+			AuditId: auditRecord.Id,
+			LogType: auditRecord.LogType,
+			LogBy:   auditRecord.LogBy,
+			LogAt:   auditRecord.LogAt,
+		}
+		switch auditRecord.LogType {
+		case CreateLog:
+			createdRecord := auditCreatedRecord(auditRecord.LogRecords, recordId)
+			if createdRecord == nil {
+				continue
+			}
+			state = copyRecord(createdRecord)
+			state["id"] = recordId
+		case UpdateLog:
+			// the updated records, prior state
+			priorRecord := auditPayloadRecord(auditPayloadField(auditRecord.LogRecords, "logRecords"), recordId)
+			updateRecord := auditUpdateRecord(auditRecord.NewLogRecords, recordId)
+			if priorRecord == nil || updateRecord == nil {
+				continue
+			}
+			state = copyRecord(priorRecord)
+			mergeRecord(state, updateRecord)
+			state["id"] = recordId
+		case DeleteLog, RemoveLog:
+			currentRecords := auditPayloadField(auditPayloadField(auditRecord.LogRecords, "logRecords"), "currentRecords")
+			priorRecord := auditPayloadRecord(currentRecords, recordId)
+			if priorRecord == nil {
+				continue
+			}
+			state = copyRecord(priorRecord)
+			version.Deleted = true
+		default:
+			continue
+		}
+		version.Record = copyRecord(state)
+		version.Version = len(versions) + 1
+		versions = append(versions, version)
+		if version.Deleted {
+			state = nil
+		}
+	}
+	return versions
+}
+
+// RecordAsOf function returns the record version at the time, i.e. the last version logged at or before the time,
+// and false, if the record did not exist (not yet created or deleted) at the time
+func RecordAsOf(versions []RecordVersionType, at time.Time) (RecordVersionType, bool) {
+	var result RecordVersionType
+	found := false
+	for _, version := range versions {
+		if version.LogAt.After(at) {
+			break
+		}
+		result = version
+		found = true
+	}
+	if !found || result.Deleted {
+		return RecordVersionType{}, false
+	}
+	return result, true
+}
+
+// getRecordAudits returns all the create, update and delete audit-log records of the table record, ascending
+func (reader *AuditReader) getRecordAudits(tableName string, recordId string) ([]AuditRecordType, mcresponse.ResponseMessage) {
+	query := AuditQueryType{
+		TableName: tableName,
+		RecordId:  recordId,
+		LogTypes:  []string{CreateLog, UpdateLog, DeleteLog, RemoveLog},
+		Ascending: true,
+	}
+	var auditRecords []AuditRecordType
+	for {
+		auditRes := reader.GetAudits(query)
+		if auditRes.Code != "success" {
+			return nil, auditRes
+		}
+		result, _ := auditRes.Value.(AuditResultType)
+		auditRecords = append(auditRecords, result.Records...)
+		if len(result.Records) < 1 || len(auditRecords) >= result.TotalCount {
+			break
+		}
+		query.Skip += len(result.Records)
+	}
+	return auditRecords, mcresponse.ResponseMessage{}
+}
+
+// GetHistory method returns the ordered versions of the table record, from the create, update and delete
+// audit-log entries
+func (reader *AuditReader) GetHistory(tableName string, recordId string) mcresponse.ResponseMessage {
+	if tableName == "" || recordId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "table name and record id are required",
+			Value:   nil,
+		})
+	}
+	auditRecords, errRes := reader.getRecordAudits(tableName, recordId)
+	if errRes.Code != "" {
+		return errRes
+	}
+	versions := BuildRecordHistory(recordId, auditRecords)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v version(s) of record %v returned", len(versions), recordId),
+		Value:   versions,
+	})
+}
+
+// GetAsOf method returns the reconstructed state (version) of the table record at the time
+func (reader *AuditReader) GetAsOf(tableName string, recordId string, at time.Time) mcresponse.ResponseMessage {
+	historyRes := reader.GetHistory(tableName, recordId)
+	if historyRes.Code != "success" {
+		return historyRes
+	}
+	versions, _ := historyRes.Value.([]RecordVersionType)
+	version, ok := RecordAsOf(versions, at)
+	if !ok {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Record %v not found (not created or deleted) at %v", recordId, at.Format(time.RFC3339)),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Version %v of record %v returned", version.Version, recordId),
+		Value:   version,
+	})
+}
@@ -0,0 +1,84 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: record history and point-in-time reconstruction test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestRecordHistory(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 3, d, 10, 0, 0, 0, time.UTC)
+	}
+	auditRecords := []AuditRecordType{
+		{
+			Id:      "audit-1",
+			LogType: CreateLog,
+			LogBy:   "user-1",
+			LogAt:   day(1),
+			LogRecords: map[string]interface{}{
+				"logRecords": []interface{}{map[string]interface{}{"name": "Books", "isActive": true}, map[string]interface{}{"name": "Toys"}},
+				"recordIds":  []interface{}{"cat-1", "cat-2"},
+			},
+		},
+		{
+			Id:      "audit-2",
+			LogType: UpdateLog,
+			LogBy:   "user-2",
+			LogAt:   day(2),
+			LogRecords: map[string]interface{}{
+				"logRecords": []interface{}{map[string]interface{}{"id": "cat-1", "name": "Books", "is_active": true}},
+			},
+			NewLogRecords: map[string]interface{}{
+				"logRecords": []interface{}{map[string]interface{}{"name": "E-Books", "isActive": false}},
+			},
+		},
+		{
+			Id:      "audit-3",
+			LogType: DeleteLog,
+			LogBy:   "user-1",
+			LogAt:   day(5),
+			LogRecords: map[string]interface{}{
+				"logRecords": map[string]interface{}{
+					"currentRecords": []interface{}{map[string]interface{}{"id": "cat-1", "name": "E-Books", "is_active": false}},
+					"recordIds":      []interface{}{"cat-1"},
+				},
+			},
+		},
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should build the ordered record versions from the audit-log entries:",
+		TestFunc: func() {
+			versions := BuildRecordHistory("cat-1", auditRecords)
+			mctest.AssertEquals(t, len(versions), 3, "three versions expected")
+			mctest.AssertEquals(t, versions[0].Record, map[string]interface{}{"id": "cat-1", "name": "Books", "isActive": true}, "created record expected")
+			mctest.AssertEquals(t, versions[1].Record, map[string]interface{}{"id": "cat-1", "name": "E-Books", "is_active": false}, "updated record expected")
+			mctest.AssertEquals(t, versions[1].LogBy, "user-2", "update logBy expected")
+			mctest.AssertEquals(t, versions[2].Deleted, true, "deleted version expected")
+			mctest.AssertEquals(t, len(BuildRecordHistory("cat-2", auditRecords)), 1, "created version only expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should reconstruct the record state at the time:",
+		TestFunc: func() {
+			versions := BuildRecordHistory("cat-1", auditRecords)
+			version, ok := RecordAsOf(versions, day(3))
+			mctest.AssertEquals(t, ok, true, "record version expected")
+			mctest.AssertEquals(t, version.Record["name"], "E-Books", "updated name expected")
+			version, _ = RecordAsOf(versions, day(1))
+			mctest.AssertEquals(t, version.Record["name"], "Books", "created name expected")
+			_, ok = RecordAsOf(versions, day(1).Add(-time.Hour))
+			mctest.AssertEquals(t, ok, false, "record not yet created expected")
+			_, ok = RecordAsOf(versions, day(6))
+			mctest.AssertEquals(t, ok, false, "deleted record expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,348 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: user registration, email-verification and password-reset, using the verify-table
+
+package mccrud
+
+import (
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/asaskevich/govalidator"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"time"
+)
+
+// verify-table token types
+const (
+	VerifyTokenType = "verify"
+	ResetTokenType  = "reset"
+)
+
+// MinPasswordLength is the minimum length of user passwords
+const MinPasswordLength = 8
+
+// RegisterParamsType is the user registration information
+type RegisterParamsType struct {
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	Firstname string `json:"firstname"`
+	Lastname  string `json:"lastname"`
+	Language  string `json:"language"`
+}
+
+// ValidateRegisterParams function returns the error-messages (by field-name) of the registration information
+func ValidateRegisterParams(params RegisterParamsType) MessageObject {
+	errMsg := MessageObject{}
+	if strings.TrimSpace(params.Username) == "" {
+		errMsg["username"] = "username is required"
+	} else if govalidator.IsEmail(params.Username) {
+		errMsg["username"] = "username may not be an email address"
+	}
+	if !govalidator.IsEmail(params.Email) {
+		errMsg["email"] = "a valid email is required"
+	}
+	if len(params.Password) < MinPasswordLength {
+		errMsg["password"] = fmt.Sprintf("password must have at least %v characters", MinPasswordLength)
+	}
+	return errMsg
+}
+
+// createVerifyToken creates a new (hashed) verify-table token for the user, and returns the token
+func (crud *Crud) createVerifyToken(tx *sqlx.Tx, userId string, tokenType string) (string, error) {
+	token, err := GenerateToken(32)
+	if err != nil {
+		return "", err
+	}
+	expire := time.Now().Add(time.Duration(crud.VerifyTimeout)*time.Second).Unix() * 1000
+	verifyScript := fmt.Sprintf("INSERT INTO %v(user_id, token, token_type, expire, created_at) VALUES ($1, $2, $3, $4, $5)", crud.VerifyTable)
+	if _, err = tx.Exec(verifyScript, userId, HashToken(token), tokenType, expire, time.Now()); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// checkVerifyToken returns the user-id of the valid (unexpired) verify-table token, and deletes (consumes) the
+// token within the transaction tx, for the single use of the token. On failure, the transaction is ended: committed
+// for the expired token (deleted), or rolled back.
+func (crud *Crud) checkVerifyToken(tx *sqlx.Tx, token string, tokenType string) (string, mcresponse.ResponseMessage) {
+	var (
+		userId string
+		expire int64
+	)
+	invalidRes := mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+		Message: "Invalid or already used token",
+		Value:   nil,
+	})
+	tokenHash := HashToken(token)
+	verifyScript := fmt.Sprintf("SELECT user_id, expire from %v WHERE token=$1 AND token_type=$2", crud.VerifyTable)
+	if err := tx.QueryRow(verifyScript, tokenHash, tokenType).Scan(&userId, &expire); err != nil {
+		_ = tx.Rollback()
+		return "", invalidRes
+	}
+	// consume the token: a concurrent use of the same token deletes no rows
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE token=$1 AND token_type=$2", crud.VerifyTable)
+	res, err := tx.Exec(delScript, tokenHash, tokenType)
+	if err != nil {
+		_ = tx.Rollback()
+		return "", mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error removing the used token: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if rowsCount, rcErr := res.RowsAffected(); rcErr != nil || rowsCount != 1 {
+		_ = tx.Rollback()
+		return "", invalidRes
+	}
+	if sessionExpired(expire, time.Now()) {
+		_ = tx.Commit()
+		return "", mcresponse.GetResMessage("tokenExpired", mcresponse.ResponseMessageOptions{
+			Message: "Token expired: please request a new token",
+			Value:   nil,
+		})
+	}
+	return userId, mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Valid token",
+		Value:   userId,
+	})
+}
+
+// Register method creates a new (inactive) user-account, and sends the email-verification token
+func (crud *Crud) Register(params RegisterParamsType) mcresponse.ResponseMessage {
+	if crud.Mailer == nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "Mailer is required for user registration",
+			Value:   nil,
+		})
+	}
+	if errMsg := ValidateRegisterParams(params); len(errMsg) > 0 {
+		return GetParamsMessage(errMsg)
+	}
+	// duplicate username/email checks
+	var count int
+	usernameScript := fmt.Sprintf("SELECT COUNT(*) from %v WHERE username=$1", crud.UserTable)
+	if err := crud.AccessDb.QueryRow(usernameScript, params.Username).Scan(&count); err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if count > 0 {
+		return mcresponse.GetResMessage("validateError", mcresponse.ResponseMessageOptions{
+			Message: crud.UsernameExistsMessage,
+			Value:   nil,
+		})
+	}
+	emailScript := fmt.Sprintf("SELECT COUNT(*) from %v WHERE email=$1", crud.UserTable)
+	if err := crud.AccessDb.QueryRow(emailScript, params.Email).Scan(&count); err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if count > 0 {
+		return mcresponse.GetResMessage("validateError", mcresponse.ResponseMessageOptions{
+			Message: crud.EmailExistsMessage,
+			Value:   nil,
+		})
+	}
+	passwordHash, err := crud.PasswordHasher.HashPassword(params.Password)
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error hashing password: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// create the user and the verification-token, in a transaction
+	tx, err := crud.AccessDb.Beginx()
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error registering user: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	var userId string
+	userScript := fmt.Sprintf("INSERT INTO %v(username, email, password, firstname, lastname, language, is_active, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id", crud.UserTable)
+	if err = tx.QueryRowx(userScript, params.Username, params.Email, passwordHash, params.Firstname, params.Lastname, params.Language, false, time.Now()).Scan(&userId); err != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error registering user: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	token, err := crud.createVerifyToken(tx, userId, VerifyTokenType)
+	if err != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating verification token: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if err = tx.Commit(); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error registering user: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// send verification-token
+	if err = crud.Mailer.SendMail(MailMessageType{
+		From:    crud.MsgFrom,
+		To:      params.Email,
+		Subject: "Verify your email address",
+		Body:    fmt.Sprintf("Use the following token to verify your email address: %v", token),
+		Token:   token,
+	}); err != nil {
+		return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("User registered, but the verification email could not be sent: %v", err.Error()),
+			Value:   userId,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Registration completed successfully. Verify your email address to activate your account",
+		Value:   userId,
+	})
+}
+
+// VerifyEmail method activates the user-account of the valid email-verification token
+func (crud *Crud) VerifyEmail(token string) mcresponse.ResponseMessage {
+	if token == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "verification token is required",
+			Value:   nil,
+		})
+	}
+	tx, err := crud.AccessDb.Beginx()
+	if err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error verifying email: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	userId, tokenRes := crud.checkVerifyToken(tx, token, VerifyTokenType)
+	if tokenRes.Code != "success" {
+		return tokenRes
+	}
+	userScript := fmt.Sprintf("UPDATE %v SET is_active=$1, updated_at=$2 WHERE id=$3", crud.UserTable)
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1 AND token_type=$2", crud.VerifyTable)
+	if _, err = tx.Exec(userScript, true, time.Now(), userId); err == nil {
+		_, err = tx.Exec(delScript, userId, VerifyTokenType)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error verifying email: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if err = tx.Commit(); err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error verifying email: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Email verified successfully. Your account is now active",
+		Value:   userId,
+	})
+}
+
+// RequestPasswordReset method sends a password-reset token to the email of the user.
+// The response is the same for registered and unknown emails.
+func (crud *Crud) RequestPasswordReset(email string) mcresponse.ResponseMessage {
+	if crud.Mailer == nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "Mailer is required for password-reset",
+			Value:   nil,
+		})
+	}
+	if !govalidator.IsEmail(email) {
+		return GetParamsMessage(MessageObject{"email": "a valid email is required"})
+	}
+	resetRes := mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "If the email is registered, a password-reset token has been sent",
+		Value:   nil,
+	})
+	var userId string
+	userScript := fmt.Sprintf("SELECT id from %v WHERE email=$1", crud.UserTable)
+	if err := crud.AccessDb.QueryRow(userScript, email).Scan(&userId); err != nil {
+		return resetRes
+	}
+	tx, err := crud.AccessDb.Beginx()
+	if err != nil {
+		return resetRes
+	}
+	token, err := crud.createVerifyToken(tx, userId, ResetTokenType)
+	if err != nil {
+		_ = tx.Rollback()
+		return resetRes
+	}
+	if err = tx.Commit(); err != nil {
+		return resetRes
+	}
+	_ = crud.Mailer.SendMail(MailMessageType{
+		From:    crud.MsgFrom,
+		To:      email,
+		Subject: "Reset your password",
+		Body:    fmt.Sprintf("Use the following token to reset your password: %v", token),
+		Token:   token,
+	})
+	return resetRes
+}
+
+// ResetPassword method updates the user password, for the valid password-reset token, and revokes all the user sessions
+func (crud *Crud) ResetPassword(token string, password string) mcresponse.ResponseMessage {
+	if token == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "password-reset token is required",
+			Value:   nil,
+		})
+	}
+	if len(password) < MinPasswordLength {
+		return GetParamsMessage(MessageObject{"password": fmt.Sprintf("password must have at least %v characters", MinPasswordLength)})
+	}
+	passwordHash, err := crud.PasswordHasher.HashPassword(password)
+	if err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error hashing password: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	tx, err := crud.AccessDb.Beginx()
+	if err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error resetting password: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	userId, tokenRes := crud.checkVerifyToken(tx, token, ResetTokenType)
+	if tokenRes.Code != "success" {
+		return tokenRes
+	}
+	userScript := fmt.Sprintf("UPDATE %v SET password=$1, updated_at=$2 WHERE id=$3", crud.UserTable)
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1 AND token_type=$2", crud.VerifyTable)
+	sessionScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1", crud.AccessTable)
+	if _, err = tx.Exec(userScript, passwordHash, time.Now(), userId); err == nil {
+		if _, err = tx.Exec(delScript, userId, ResetTokenType); err == nil {
+			_, err = tx.Exec(sessionScript, userId)
+		}
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error resetting password: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if err = tx.Commit(); err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error resetting password: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	InvalidateUserAccessCache(userId)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Password reset completed successfully. Please login to continue",
+		Value:   userId,
+	})
+}
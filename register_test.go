@@ -0,0 +1,73 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: user registration helpers test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should validate the registration information:",
+		TestFunc: func() {
+			errMsg := ValidateRegisterParams(RegisterParamsType{Username: "abbeymart", Email: "abbeymart@mconnect.biz", Password: "secret-pass"})
+			mctest.AssertEquals(t, len(errMsg), 0, "no validation error expected")
+			errMsg = ValidateRegisterParams(RegisterParamsType{Username: "abbeymart@mconnect.biz", Email: "abbeymart", Password: "secret"})
+			mctest.AssertEquals(t, len(errMsg), 3, "username, email and password errors expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should hash and compare passwords:",
+		TestFunc: func() {
+			hasher := NewBcryptPasswordHasher(4)
+			hash, err := hasher.HashPassword("secret-pass")
+			mctest.AssertEquals(t, err, nil, "no hash error expected")
+			mctest.AssertEquals(t, hash != "secret-pass", true, "password should be hashed")
+			mctest.AssertEquals(t, hasher.ComparePassword(hash, "secret-pass"), nil, "password should match")
+			mctest.AssertEquals(t, hasher.ComparePassword(hash, "wrong-pass") != nil, true, "wrong password should not match")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should generate unique tokens and stable token-hashes:",
+		TestFunc: func() {
+			token1, _ := GenerateToken(32)
+			token2, _ := GenerateToken(32)
+			mctest.AssertEquals(t, len(token1), 64, "hex-token of 64 characters expected")
+			mctest.AssertEquals(t, token1 != token2, true, "tokens should be unique")
+			mctest.AssertEquals(t, HashToken(token1), HashToken(token1), "token-hash should be stable")
+			mctest.AssertEquals(t, HashToken(token1) != token1, true, "token should be hashed")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should store and return the last mail message of the recipient:",
+		TestFunc: func() {
+			mailer := NewMemoryMailer()
+			_ = mailer.SendMail(MailMessageType{To: "abbeymart@mconnect.biz", Subject: "Verify", Token: "token-1"})
+			_ = mailer.SendMail(MailMessageType{To: "abbeymart@mconnect.biz", Subject: "Reset", Token: "token-2"})
+			msg, ok := mailer.LastMessage("abbeymart@mconnect.biz")
+			mctest.AssertEquals(t, ok, true, "mail message expected")
+			mctest.AssertEquals(t, msg.Token, "token-2", "last token expected")
+			mailer.Reset()
+			_, ok = mailer.LastMessage("abbeymart@mconnect.biz")
+			mctest.AssertEquals(t, ok, false, "no mail message expected after reset")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should require the verify and password-reset tokens:",
+		TestFunc: func() {
+			crud := Crud{}
+			mctest.AssertEquals(t, crud.VerifyEmail("").Code, "paramsError", "verification token required")
+			mctest.AssertEquals(t, crud.ResetPassword("", "secret-pass").Code, "paramsError", "password-reset token required")
+			mctest.AssertEquals(t, crud.ResetPassword("token-1", "secret").Code, "validateError", "minimum password length required")
+		},
+	})
+
+	mctest.PostTestResult()
+}
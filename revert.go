@@ -0,0 +1,494 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: revert (undo) the update and delete operations, from the audit-log entries
+
+package mccrud
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/asaskevich/govalidator"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RevertRecordType is the update revert of a record: the restore (logged prior) values and the expected current
+// (logged new) values, by underscore field names
+type RevertRecordType struct {
+	RecordId string                 `json:"recordId"`
+	Fields   map[string]interface{} `json:"fields"`
+	Expected map[string]interface{} `json:"expected"`
+}
+
+// RevertPlanType is the revert of the audit-log entry: the updated records fields or the deleted records
+type RevertPlanType struct {
+	AuditId   string                   `json:"auditId"`
+	TableName string                   `json:"tableName"`
+	LogType   string                   `json:"logType"`
+	Updates   []RevertRecordType       `json:"updates"`
+	Restores  []map[string]interface{} `json:"restores"`
+}
+
+// RevertConflictType is a change, after the audit-log entry, that prevents the revert
+type RevertConflictType struct {
+	RecordId string      `json:"recordId"`
+	Field    string      `json:"field"`
+	Expected interface{} `json:"expected"`
+	Current  interface{} `json:"current"`
+	Message  string      `json:"message"`
+}
+
+// RecordIds method returns the record ids of the revert plan
+func (plan RevertPlanType) RecordIds() []string {
+	var recordIds []string
+	for _, rec := range plan.Updates {
+		recordIds = append(recordIds, rec.RecordId)
+	}
+	for _, rec := range plan.Restores {
+		recordIds = append(recordIds, fmt.Sprintf("%v", rec["id"]))
+	}
+	return recordIds
+}
+
+// auditLogRecords returns the records of the decoded audit-log payload, logged as LogRecordsType or records
+func auditLogRecords(payload interface{}) interface{} {
+	if records, ok := payload.([]interface{}); ok {
+		return records
+	}
+	return auditPayloadField(payload, "logRecords")
+}
+
+// underscoreRecord returns the record with the underscore field names
+func underscoreRecord(record map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for fieldName, val := range record {
+		result[govalidator.CamelCaseToUnderscore(fieldName)] = val
+	}
+	return result
+}
+
+// ComputeRevertPlan function returns the revert plan of the update (restore the updated fields) or delete (restore
+// the deleted records) audit-log record
+func ComputeRevertPlan(auditRecord AuditRecordType) (RevertPlanType, error) {
+	plan := RevertPlanType{
+		AuditId:   auditRecord.Id,
+		TableName: auditRecord.TableName,
+		LogType:   auditRecord.LogType,
+	}
+	switch auditRecord.LogType {
+	case UpdateLog:
+		priorRecords, _ := auditLogRecords(auditRecord.LogRecords).([]interface{})
+		for _, priorRec := range priorRecords {
+			priorRecord, ok := priorRec.(map[string]interface{})
+			if !ok || priorRecord["id"] == nil {
+				continue
+			}
+			recordId := fmt.Sprintf("%v", priorRecord["id"])
+			updateRecord := auditUpdateRecord(auditRecord.NewLogRecords, recordId)
+			if updateRecord == nil {
+				updateRecord = auditPayloadRecord(auditLogRecords(auditRecord.NewLogRecords), recordId)
+			}
+			if updateRecord == nil {
+				continue
+			}
+			priorFields := underscoreRecord(priorRecord)
+			revertRecord := RevertRecordType{
+				RecordId: recordId,
+				Fields:   map[string]interface{}{},
+				Expected: map[string]interface{}{},
+			}
+			for fieldName, val := range underscoreRecord(updateRecord) {
+				priorVal, ok := priorFields[fieldName]
+				if !ok || ArrayStringContains(fieldWriteExempted, fieldName) {
+					continue
+				}
+				revertRecord.Fields[fieldName] = priorVal
+				revertRecord.Expected[fieldName] = val
+			}
+			if len(revertRecord.Fields) > 0 {
+				plan.Updates = append(plan.Updates, revertRecord)
+			}
+		}
+	case DeleteLog, RemoveLog:
+		currentRecords, _ := auditPayloadField(auditLogRecords(auditRecord.LogRecords), "currentRecords").([]interface{})
+		for _, currentRec := range currentRecords {
+			if record, ok := currentRec.(map[string]interface{}); ok && record["id"] != nil {
+				plan.Restores = append(plan.Restores, underscoreRecord(record))
+			}
+		}
+	default:
+		return plan, errors.New("only the update and delete audit-log entries may be reverted")
+	}
+	if len(plan.Updates) < 1 && len(plan.Restores) < 1 {
+		return plan, errors.New("no revertible records found in the audit-log entry")
+	}
+	return plan, nil
+}
+
+// revertValue returns the comparable value of the db or audit-log (json) value
+func revertValue(val interface{}) string {
+	switch v := val.(type) {
+	case []byte:
+		return revertValue(string(v))
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return t.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return auditDiffValue(val)
+}
+
+// revertDbValue returns the db value of the audit-log (json) value, json objects and arrays are json-encoded
+func revertDbValue(val interface{}) interface{} {
+	switch val.(type) {
+	case map[string]interface{}, []interface{}:
+		jsonVal, _ := json.Marshal(val)
+		return string(jsonVal)
+	}
+	return val
+}
+
+// RevertConflicts function returns the revert plan updates conflicts, the fields changed after the audit-log entry,
+// of the current records (by id)
+func RevertConflicts(plan RevertPlanType, currentRecords map[string]map[string]interface{}) []RevertConflictType {
+	var conflicts []RevertConflictType
+	for _, rec := range plan.Updates {
+		currentRecord, ok := currentRecords[rec.RecordId]
+		if !ok {
+			conflicts = append(conflicts, RevertConflictType{
+				RecordId: rec.RecordId,
+				Message:  "Record not found, deleted after the audit-log entry",
+			})
+			continue
+		}
+		currentFields := underscoreRecord(currentRecord)
+		var fieldNames []string
+		for fieldName := range rec.Expected {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			if revertValue(currentFields[fieldName]) != revertValue(rec.Expected[fieldName]) {
+				conflicts = append(conflicts, RevertConflictType{
+					RecordId: rec.RecordId,
+					Field:    fieldName,
+					Expected: rec.Expected[fieldName],
+					Current:  currentFields[fieldName],
+					Message:  "Field changed after the audit-log entry",
+				})
+			}
+		}
+	}
+	return conflicts
+}
+
+// ValidateRevertPlan function returns the revert plan fields conflicts, of the model (underscore) fields and the
+// redacted (comparable) fields of the table: the reverted fields must be the model fields, and the restored records
+// must not include the redacted (dropped, masked or hashed) fields, as the logged records are incomplete
+func ValidateRevertPlan(plan RevertPlanType, modelFields map[string]interface{}, redactedFields []string) []RevertConflictType {
+	var conflicts []RevertConflictType
+	checkFields := func(recordId string, fields map[string]interface{}) {
+		var fieldNames []string
+		for fieldName := range fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		for _, fieldName := range fieldNames {
+			if _, ok := modelFields[fieldName]; !ok {
+				conflicts = append(conflicts, RevertConflictType{
+					RecordId: recordId,
+					Field:    fieldName,
+					Message:  "Unknown field of the table model",
+				})
+			}
+		}
+	}
+	for _, rec := range plan.Updates {
+		checkFields(rec.RecordId, rec.Fields)
+	}
+	if len(plan.Restores) < 1 {
+		return conflicts
+	}
+	// the redacted fields of the table model or the restored records
+	var redacted []string
+	for _, rec := range plan.Restores {
+		checkFields(fmt.Sprintf("%v", rec["id"]), rec)
+		for fieldName := range rec {
+			if ArrayStringContains(redactedFields, redactFieldName(fieldName)) && !ArrayStringContains(redacted, fieldName) {
+				redacted = append(redacted, fieldName)
+			}
+		}
+	}
+	for fieldName := range modelFields {
+		if ArrayStringContains(redactedFields, redactFieldName(fieldName)) && !ArrayStringContains(redacted, fieldName) {
+			redacted = append(redacted, fieldName)
+		}
+	}
+	sort.Strings(redacted)
+	for _, fieldName := range redacted {
+		conflicts = append(conflicts, RevertConflictType{
+			Field:   fieldName,
+			Message: "Redacted field of the logged records: the deleted records may not be restored",
+		})
+	}
+	return conflicts
+}
+
+// revertAccess checks the update (updated records) or create (deleted records) task permission of the revert
+func (crud *Crud) revertAccess(plan RevertPlanType) mcresponse.ResponseMessage {
+	crud.ActionParams = ActionParamsType{}
+	crud.RecordIds = plan.RecordIds()
+	if len(plan.Updates) > 0 {
+		for _, rec := range plan.Updates {
+			actionParam := ActionParamType{"id": rec.RecordId}
+			for fieldName, val := range rec.Fields {
+				actionParam[fieldName] = val
+			}
+			crud.ActionParams = append(crud.ActionParams, actionParam)
+		}
+		crud.TaskType = UpdateTask
+		return crud.TaskAccess(crud.TaskType, func() mcresponse.ResponseMessage {
+			return crud.TaskPermissionById(crud.TaskType)
+		})
+	}
+	for _, rec := range plan.Restores {
+		crud.ActionParams = append(crud.ActionParams, rec)
+	}
+	crud.TaskType = CreateTask
+	return crud.TaskAccess(crud.TaskType, crud.CheckTaskAccess)
+}
+
+// Revert method reverts the update (restores the logged prior field values) or delete (restores the deleted records)
+// audit-log entry of the crud table, in a transaction. The revert is aborted by the changes after the audit-log entry
+// (conflicts). The revert is access-checked and audit-logged.
+func (crud *Crud) Revert(auditId string) mcresponse.ResponseMessage {
+	if auditId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "audit id is required",
+			Value:   nil,
+		})
+	}
+	// validate app-access, in multi-tenant mode
+	if crud.TenantMode {
+		if appRes := crud.CheckAppAccess(); appRes.Code != "success" {
+			return appRes
+		}
+	}
+	auditRes := crud.auditReader().GetAudit(auditId)
+	if auditRes.Code != "success" {
+		return auditRes
+	}
+	auditRecord, _ := auditRes.Value.(AuditRecordType)
+	if auditRecord.TableName != crud.TableName {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Audit-log entry %v is not of the table %v", auditId, crud.TableName),
+			Value:   nil,
+		})
+	}
+	plan, err := ComputeRevertPlan(auditRecord)
+	var redactedFields []string
+	if err == nil && crud.AuditRedactor != nil {
+		plan = crud.AuditRedactor.RevertPlan(plan)
+		redactedFields = crud.AuditRedactor.RedactedFields(crud.TableName)
+		if len(plan.Updates) < 1 && len(plan.Restores) < 1 {
+			err = errors.New("no revertible (non-redacted) fields found in the audit-log entry")
+		}
+	}
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	// validate the reverted fields, of the table model
+	if crud.ModelRef == nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "Table model (ModelRef) is required",
+			Value:   nil,
+		})
+	}
+	modelFields, err := StructToMapUnderscore(crud.ModelRef)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Table model (ModelRef) is required: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if fieldConflicts := ValidateRevertPlan(plan, modelFields, redactedFields); len(fieldConflicts) > 0 {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Revert aborted: %v invalid or redacted field(s) of the audit-log entry", len(fieldConflicts)),
+			Value:   fieldConflicts,
+		})
+	}
+	// check task-permission
+	if accessRes := crud.revertAccess(plan); accessRes.Code != "success" {
+		return accessRes
+	}
+	tx, err := crud.AppDb.Beginx()
+	if err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reverting record(s): %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	// current records and conflicts, locked until the revert commit (sqlite: the transaction is serialized)
+	lockScript := " FOR UPDATE"
+	if crud.AppDb.DriverName() == "sqlite3" {
+		lockScript = ""
+	}
+	currentRecords := map[string]map[string]interface{}{}
+	var conflicts []RevertConflictType
+	for _, recordId := range plan.RecordIds() {
+		selectWhere, selectValues := crud.TenantWhere("WHERE id=$1", []interface{}{recordId})
+		selectScript := fmt.Sprintf("SELECT * FROM %v %v%v", crud.TableName, selectWhere, lockScript)
+		currentRecord := map[string]interface{}{}
+		if err = tx.QueryRowx(selectScript, selectValues...).MapScan(currentRecord); err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Db query Error: %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		for fieldName, val := range currentRecord {
+			if byteVal, ok := val.([]byte); ok {
+				currentRecord[fieldName] = string(byteVal)
+			}
+		}
+		currentRecords[recordId] = currentRecord
+	}
+	conflicts = RevertConflicts(plan, currentRecords)
+	for _, rec := range plan.Restores {
+		recordId := fmt.Sprintf("%v", rec["id"])
+		if _, ok := currentRecords[recordId]; ok {
+			conflicts = append(conflicts, RevertConflictType{
+				RecordId: recordId,
+				Message:  "Record exists, re-created after the audit-log entry",
+			})
+		} else if crud.TenantMode && fmt.Sprintf("%v", rec["app_id"]) != crud.AppParams.AppId {
+			conflicts = append(conflicts, RevertConflictType{
+				RecordId: recordId,
+				Message:  "Record is not of the current app/tenant",
+			})
+		}
+	}
+	if len(conflicts) > 0 {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Revert aborted: %v conflicting change(s) after the audit-log entry", len(conflicts)),
+			Value:   conflicts,
+		})
+	}
+	// revert the updated records fields
+	var logRecords, newLogRecords []map[string]interface{}
+	for _, rec := range plan.Updates {
+		var fieldNames []string
+		for fieldName := range rec.Fields {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		var setFields []string
+		var values []interface{}
+		newLogRecord := map[string]interface{}{"id": rec.RecordId}
+		for _, fieldName := range fieldNames {
+			values = append(values, revertDbValue(rec.Fields[fieldName]))
+			setFields = append(setFields, fmt.Sprintf("%v=$%v", fieldName, len(values)))
+			newLogRecord[fieldName] = rec.Fields[fieldName]
+		}
+		if crud.ModelOptions.ActorStamp {
+			values = append(values, crud.UserInfo.UserId)
+			setFields = append(setFields, fmt.Sprintf("updated_by=$%v", len(values)))
+		}
+		if crud.ModelOptions.TimeStamp {
+			values = append(values, time.Now())
+			setFields = append(setFields, fmt.Sprintf("updated_at=$%v", len(values)))
+		}
+		values = append(values, rec.RecordId)
+		updateWhere, updateValues := crud.TenantWhere(fmt.Sprintf("WHERE id=$%v", len(values)), values)
+		updateScript := fmt.Sprintf("UPDATE %v SET %v %v", crud.TableName, strings.Join(setFields, ", "), updateWhere)
+		if _, err = tx.Exec(updateScript, updateValues...); err != nil {
+			return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reverting record(s): %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		logRecords = append(logRecords, currentRecords[rec.RecordId])
+		newLogRecords = append(newLogRecords, newLogRecord)
+	}
+	// restore the deleted records
+	for _, rec := range plan.Restores {
+		var fieldNames, placeholders []string
+		for fieldName := range rec {
+			fieldNames = append(fieldNames, fieldName)
+		}
+		sort.Strings(fieldNames)
+		var values []interface{}
+		for _, fieldName := range fieldNames {
+			values = append(values, revertDbValue(rec[fieldName]))
+			placeholders = append(placeholders, fmt.Sprintf("$%v", len(values)))
+		}
+		insertScript := fmt.Sprintf("INSERT INTO %v(%v) VALUES (%v)", crud.TableName, strings.Join(fieldNames, ", "), strings.Join(placeholders, ", "))
+		if _, err = tx.Exec(insertScript, values...); err != nil {
+			return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error restoring record(s): %v", err.Error()),
+				Value:   nil,
+			})
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reverting record(s): %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// delete cache, and the cached access information of the access tables
+	crud.invalidateCache()
+	if crud.AccessCache {
+		crud.invalidateAccessTableCache(crud.TableName, plan.RecordIds())
+	}
+	// perform audit-log, for all reverts, with the reverted audit id
+	revertParam := QueryParamType{"revertAuditId": auditId}
+	auditInfo := AuditLogOptionsType{
+		TableName:  crud.TableName,
+		LogRecords: LogRecordsType{LogRecords: plan.Restores, RecordIds: plan.RecordIds(), QueryParam: revertParam},
+		RecordIds:  plan.RecordIds(),
+	}
+	logType := CreateLog
+	if len(plan.Updates) > 0 {
+		logType = UpdateLog
+		auditInfo.LogRecords = LogRecordsType{LogRecords: logRecords, QueryParam: revertParam}
+		auditInfo.NewLogRecords = LogRecordsType{LogRecords: newLogRecords}
+		if crud.AuditDiff {
+			var updateRecords ActionParamsType
+			for _, rec := range newLogRecords {
+				updateRecords = append(updateRecords, rec)
+			}
+			auditInfo.LogDiff = ComputeRecordDiffs(logRecords, updateRecords)
+		}
+	}
+	logMessage := ""
+	logRes, logErr := crud.TransLog.AuditLog(logType, crud.UserInfo.UserId, auditInfo)
+	if logErr != nil {
+		logMessage = fmt.Sprintf(" | Audit-log-error: %v", logErr.Error())
+	} else {
+		logMessage = fmt.Sprintf(" | Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Audit-log entry %v reverted: %v record(s)%v", auditId, len(plan.RecordIds()), logMessage),
+		Value: CrudResultType{
+			RecordIds:    plan.RecordIds(),
+			RecordsCount: len(plan.RecordIds()),
+			TaskType:     crud.TaskType,
+			LogRes:       logRes,
+		},
+	})
+}
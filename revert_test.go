@@ -0,0 +1,161 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: audit-log revert plan, conflicts and fields validation test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestRevert(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the revert plans of the update and delete audit-log entries:",
+		TestFunc: func() {
+			testCases := []struct {
+				name        string
+				auditRecord AuditRecordType
+				updates     []RevertRecordType
+				restores    []map[string]interface{}
+				isError     bool
+			}{
+				{
+					name: "update by ids",
+					auditRecord: AuditRecordType{TableName: "groups", LogType: UpdateLog,
+						LogRecords:    map[string]interface{}{"logRecords": []interface{}{map[string]interface{}{"id": "g-1", "name": "Old", "updatedBy": "user-1"}}},
+						NewLogRecords: map[string]interface{}{"logRecords": []interface{}{map[string]interface{}{"id": "g-1", "name": "New", "updatedBy": "user-2"}}},
+					},
+					updates: []RevertRecordType{{RecordId: "g-1", Fields: map[string]interface{}{"name": "Old"}, Expected: map[string]interface{}{"name": "New"}}},
+				},
+				{
+					name: "update by params, of the record without id",
+					auditRecord: AuditRecordType{TableName: "groups", LogType: UpdateLog,
+						LogRecords:    []interface{}{map[string]interface{}{"id": "g-1", "isActive": true, "name": "Old"}},
+						NewLogRecords: map[string]interface{}{"logRecords": []interface{}{map[string]interface{}{"isActive": false}}},
+					},
+					updates: []RevertRecordType{{RecordId: "g-1", Fields: map[string]interface{}{"is_active": true}, Expected: map[string]interface{}{"is_active": false}}},
+				},
+				{
+					name: "delete",
+					auditRecord: AuditRecordType{TableName: "groups", LogType: DeleteLog,
+						LogRecords: map[string]interface{}{"logRecords": map[string]interface{}{"currentRecords": []interface{}{map[string]interface{}{"id": "g-1", "firstName": "Abi"}}}},
+					},
+					restores: []map[string]interface{}{{"id": "g-1", "first_name": "Abi"}},
+				},
+				{
+					name:        "create",
+					auditRecord: AuditRecordType{TableName: "groups", LogType: CreateLog},
+					isError:     true,
+				},
+				{
+					name: "update without the updated records",
+					auditRecord: AuditRecordType{TableName: "groups", LogType: UpdateLog,
+						LogRecords: map[string]interface{}{"logRecords": []interface{}{map[string]interface{}{"id": "g-1", "name": "Old"}}},
+					},
+					isError: true,
+				},
+			}
+			for _, testCase := range testCases {
+				plan, err := ComputeRevertPlan(testCase.auditRecord)
+				mctest.AssertEquals(t, err != nil, testCase.isError, testCase.name+": plan error expected")
+				if testCase.isError {
+					continue
+				}
+				mctest.AssertEquals(t, plan.Updates, testCase.updates, testCase.name+": plan updates expected")
+				mctest.AssertEquals(t, plan.Restores, testCase.restores, testCase.name+": plan restores expected")
+			}
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the revert conflicts, of the current records:",
+		TestFunc: func() {
+			updatedAt := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+			plan := RevertPlanType{Updates: []RevertRecordType{{
+				RecordId: "g-1",
+				Fields:   map[string]interface{}{"name": "Old", "due_at": "2026-10-18T08:00:00Z"},
+				Expected: map[string]interface{}{"name": "New", "due_at": "2026-10-19T09:00:00+01:00"},
+			}}}
+			testCases := []struct {
+				name           string
+				currentRecords map[string]map[string]interface{}
+				conflicts      []RevertConflictType
+			}{
+				{
+					name:           "unchanged record, of the db values",
+					currentRecords: map[string]map[string]interface{}{"g-1": {"name": []byte("New"), "dueAt": updatedAt}},
+				},
+				{
+					name:           "changed field",
+					currentRecords: map[string]map[string]interface{}{"g-1": {"name": "Newer", "due_at": updatedAt}},
+					conflicts:      []RevertConflictType{{RecordId: "g-1", Field: "name", Expected: "New", Current: "Newer", Message: "Field changed after the audit-log entry"}},
+				},
+				{
+					name:           "deleted record",
+					currentRecords: map[string]map[string]interface{}{},
+					conflicts:      []RevertConflictType{{RecordId: "g-1", Message: "Record not found, deleted after the audit-log entry"}},
+				},
+			}
+			for _, testCase := range testCases {
+				mctest.AssertEquals(t, RevertConflicts(plan, testCase.currentRecords), testCase.conflicts, testCase.name+": conflicts expected")
+			}
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should validate the reverted fields, of the table model and the redacted fields:",
+		TestFunc: func() {
+			modelFields := map[string]interface{}{"id": "", "name": "", "email": ""}
+			redactedFields := NewAuditRedactor(nil).RedactedFields("users")
+			testCases := []struct {
+				name           string
+				plan           RevertPlanType
+				redactedFields []string
+				conflicts      []RevertConflictType
+			}{
+				{
+					name: "model fields",
+					plan: RevertPlanType{
+						Updates:  []RevertRecordType{{RecordId: "u-1", Fields: map[string]interface{}{"name": "Abi"}}},
+						Restores: []map[string]interface{}{{"id": "u-2", "name": "Ola", "email": "ola@mconnect.biz"}},
+					},
+				},
+				{
+					name: "unknown (injected) update field",
+					plan: RevertPlanType{Updates: []RevertRecordType{{RecordId: "u-1", Fields: map[string]interface{}{"name=name, is_admin": true}}}},
+					conflicts: []RevertConflictType{
+						{RecordId: "u-1", Field: "name=name, is_admin", Message: "Unknown field of the table model"},
+					},
+				},
+				{
+					name:           "redacted update field, removed by the redactor revert plan",
+					plan:           RevertPlanType{Updates: []RevertRecordType{{RecordId: "u-1", Fields: map[string]interface{}{"name": "Abi"}}}},
+					redactedFields: redactedFields,
+				},
+				{
+					name:           "masked restore field",
+					plan:           RevertPlanType{Restores: []map[string]interface{}{{"id": "u-2", "name": "Ola", "email": "o**@mconnect.biz"}}},
+					redactedFields: redactedFields,
+					conflicts: []RevertConflictType{
+						{Field: "email", Message: "Redacted field of the logged records: the deleted records may not be restored"},
+					},
+				},
+				{
+					name:           "dropped restore field, of the table model",
+					plan:           RevertPlanType{Restores: []map[string]interface{}{{"id": "u-2", "name": "Ola"}}},
+					redactedFields: []string{"email"},
+					conflicts: []RevertConflictType{
+						{Field: "email", Message: "Redacted field of the logged records: the deleted records may not be restored"},
+					},
+				},
+			}
+			for _, testCase := range testCases {
+				mctest.AssertEquals(t, ValidateRevertPlan(testCase.plan, modelFields, testCase.redactedFields), testCase.conflicts, testCase.name+": conflicts expected")
+			}
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,229 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: hierarchical role inheritance, multi-role (union) evaluation and effective role-services cache
+
+package mccrud
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// roleCacheItem is the cached effective role-services of a user, for the specified service items
+type roleCacheItem struct {
+	roleServices []RoleServiceType
+	expire       time.Time
+}
+
+var (
+	roleCache      = map[string]roleCacheItem{}
+	roleCacheMutex sync.RWMutex
+)
+
+// roleCacheKey computes the cache-key of the user, the role-services source (access db, role and role-inheritance
+// tables), the role-services fields (field permissions, if CheckFieldAccess, and deny, if RoleHierarchy), the user
+// roles and the service items
+func (crud *Crud) roleCacheKey(userId string, roleIds []string, serviceIds []string) string {
+	roles := append([]string{}, roleIds...)
+	sort.Strings(roles)
+	ids := append([]string{}, serviceIds...)
+	sort.Strings(ids)
+	roleInheritTable := ""
+	if crud.RoleHierarchy {
+		roleInheritTable = crud.RoleInheritTable
+	}
+	return fmt.Sprintf("%v|%p|%v|%v|%v|%v|%v|%v", userId, crud.AccessDb, crud.RoleTable, roleInheritTable, crud.CheckFieldAccess,
+		crud.RoleHierarchy, strings.Join(roles, ","), strings.Join(ids, ","))
+}
+
+// InvalidateRoleCache removes the cached effective role-services of the user, e.g. after role/permission changes
+func InvalidateRoleCache(userId string) {
+	roleCacheMutex.Lock()
+	defer roleCacheMutex.Unlock()
+	for key := range roleCache {
+		if strings.HasPrefix(key, userId+"|") {
+			delete(roleCache, key)
+		}
+	}
+}
+
+// ClearRoleCache removes the cached effective role-services of all users
+func ClearRoleCache() {
+	roleCacheMutex.Lock()
+	defer roleCacheMutex.Unlock()
+	roleCache = map[string]roleCacheItem{}
+}
+
+// ResolveRoleHierarchy function returns the roleIds and all their inherited (ancestor) roles, from the
+// role-parents (roleId => parent roleIds). It returns an error for cyclic role inheritance.
+func ResolveRoleHierarchy(roleIds []string, roleParents map[string][]string) ([]string, error) {
+	var effectiveRoles []string
+	visited := map[string]bool{}
+	inPath := map[string]bool{}
+	var path []string
+	var visit func(roleId string) error
+	visit = func(roleId string) error {
+		if inPath[roleId] {
+			return errors.New(fmt.Sprintf("cyclic role inheritance: %v -> %v", strings.Join(path, " -> "), roleId))
+		}
+		if visited[roleId] {
+			return nil
+		}
+		visited[roleId] = true
+		inPath[roleId] = true
+		path = append(path, roleId)
+		effectiveRoles = append(effectiveRoles, roleId)
+		for _, parentId := range roleParents[roleId] {
+			if err := visit(parentId); err != nil {
+				return err
+			}
+		}
+		inPath[roleId] = false
+		path = path[:len(path)-1]
+		return nil
+	}
+	for _, roleId := range roleIds {
+		if roleId == "" {
+			continue
+		}
+		if err := visit(roleId); err != nil {
+			return nil, err
+		}
+	}
+	return effectiveRoles, nil
+}
+
+// MergeRoleServices function computes the effective permission of each service item, as the union of the allow
+// role-services, less the permissions of the deny role-services (deny-overrides-allow). The service items without
+// any effective permission (denied or deny-only) are excluded.
+func MergeRoleServices(roleServices []RoleServiceType) []RoleServiceType {
+	var serviceIds []string
+	allowServices := map[string]RoleServiceType{}
+	denyServices := map[string]RoleServiceType{}
+	for _, rs := range roleServices {
+		if _, ok := allowServices[rs.ServiceId]; !ok {
+			serviceIds = append(serviceIds, rs.ServiceId)
+			allowServices[rs.ServiceId] = RoleServiceType{
+				ServiceId:       rs.ServiceId,
+				RoleId:          rs.RoleId,
+				ServiceCategory: rs.ServiceCategory,
+			}
+		}
+		if rs.IsDeny {
+			deny := denyServices[rs.ServiceId]
+			deny.CanRead = deny.CanRead || rs.CanRead
+			deny.CanCreate = deny.CanCreate || rs.CanCreate
+			deny.CanUpdate = deny.CanUpdate || rs.CanUpdate
+			deny.CanDelete = deny.CanDelete || rs.CanDelete
+			deny.CanCrud = deny.CanCrud || rs.CanCrud
+			denyServices[rs.ServiceId] = deny
+			continue
+		}
+		allow := allowServices[rs.ServiceId]
+		if !ArrayStringContains(allow.RoleIds, rs.RoleId) {
+			allow.RoleIds = append(allow.RoleIds, rs.RoleId)
+		}
+		allow.CanRead = allow.CanRead || rs.CanRead
+		allow.CanCreate = allow.CanCreate || rs.CanCreate
+		allow.CanUpdate = allow.CanUpdate || rs.CanUpdate
+		allow.CanDelete = allow.CanDelete || rs.CanDelete
+		allow.CanCrud = allow.CanCrud || rs.CanCrud
+		allow.FieldPermissions = append(allow.FieldPermissions, rs.FieldPermissions...)
+		allowServices[rs.ServiceId] = allow
+	}
+	var effectiveServices []RoleServiceType
+	for _, serviceId := range serviceIds {
+		rs := allowServices[serviceId]
+		if deny, ok := denyServices[serviceId]; ok {
+			if deny.CanCrud {
+				deny.CanRead, deny.CanCreate, deny.CanUpdate, deny.CanDelete = true, true, true, true
+			}
+			rs.CanRead = rs.CanRead && !deny.CanRead
+			rs.CanCreate = rs.CanCreate && !deny.CanCreate
+			rs.CanUpdate = rs.CanUpdate && !deny.CanUpdate
+			rs.CanDelete = rs.CanDelete && !deny.CanDelete
+			rs.CanCrud = rs.CanCrud && !deny.CanCrud && !deny.CanRead && !deny.CanCreate && !deny.CanUpdate && !deny.CanDelete
+		}
+		if !rs.CanRead && !rs.CanCreate && !rs.CanUpdate && !rs.CanDelete && !rs.CanCrud {
+			continue
+		}
+		effectiveServices = append(effectiveServices, rs)
+	}
+	return effectiveServices
+}
+
+// GetRoleParents method returns the role-parents (roleId => parent roleIds) of the roleIds and their ancestors
+func (crud *Crud) GetRoleParents(accessDb *sqlx.DB, roleInheritTable string, roleIds []string) (map[string][]string, error) {
+	roleParents := map[string][]string{}
+	queried := map[string]bool{}
+	pendingIds := roleIds
+	for len(pendingIds) > 0 {
+		for _, id := range pendingIds {
+			queried[id] = true
+		}
+		inheritScript := fmt.Sprintf("SELECT role_id, parent_role_id from %v WHERE role_id IN (%v)", roleInheritTable, ArrayToSQLStringValues(pendingIds))
+		rows, err := accessDb.Queryx(inheritScript)
+		if err != nil {
+			return nil, err
+		}
+		var nextIds []string
+		for rows.Next() {
+			var roleId, parentRoleId string
+			if err := rows.Scan(&roleId, &parentRoleId); err == nil {
+				roleParents[roleId] = append(roleParents[roleId], parentRoleId)
+				if !queried[parentRoleId] && !ArrayStringContains(nextIds, parentRoleId) {
+					nextIds = append(nextIds, parentRoleId)
+				}
+			}
+		}
+		_ = rows.Close()
+		pendingIds = nextIds
+	}
+	return roleParents, nil
+}
+
+// GetEffectiveRoleServices method returns the (cached) effective role-services of the user roles, including the
+// inherited roles, for the specified service items
+func (crud *Crud) GetEffectiveRoleServices(userId string, userRoleIds []string, serviceIds []string) ([]RoleServiceType, error) {
+	var roleIds []string
+	for _, roleId := range userRoleIds {
+		if roleId != "" && !ArrayStringContains(roleIds, roleId) {
+			roleIds = append(roleIds, roleId)
+		}
+	}
+	if len(roleIds) < 1 {
+		return nil, nil
+	}
+	cacheKey := crud.roleCacheKey(userId, roleIds, serviceIds)
+	roleCacheMutex.RLock()
+	cacheItem, ok := roleCache[cacheKey]
+	roleCacheMutex.RUnlock()
+	if ok && time.Now().Before(cacheItem.expire) {
+		return cacheItem.roleServices, nil
+	}
+	roleParents, err := crud.GetRoleParents(crud.AccessDb, crud.RoleInheritTable, roleIds)
+	if err != nil {
+		return nil, err
+	}
+	effectiveRoles, err := ResolveRoleHierarchy(roleIds, roleParents)
+	if err != nil {
+		return nil, err
+	}
+	roleServices, err := crud.GetRoleServicesByRoleIds(crud.AccessDb, crud.RoleTable, effectiveRoles, serviceIds)
+	if err != nil {
+		return nil, err
+	}
+	effectiveServices := MergeRoleServices(roleServices)
+	roleCacheMutex.Lock()
+	roleCache[cacheKey] = roleCacheItem{
+		roleServices: effectiveServices,
+		expire:       time.Now().Add(time.Duration(crud.RoleCacheExpire) * time.Second),
+	}
+	roleCacheMutex.Unlock()
+	return effectiveServices, nil
+}
@@ -0,0 +1,127 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: role inheritance and multi-role evaluation test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+func TestRoleHierarchy(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should resolve the roles and the inherited (parent) roles:",
+		TestFunc: func() {
+			roleParents := map[string][]string{
+				"manager": {"staff"},
+				"staff":   {"guest"},
+				"auditor": {"guest"},
+			}
+			roles, err := ResolveRoleHierarchy([]string{"manager", "auditor"}, roleParents)
+			mctest.AssertEquals(t, err, nil, "no resolve error expected")
+			mctest.AssertEquals(t, roles, []string{"manager", "staff", "guest", "auditor"}, "effective roles should include the inherited roles, once")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return error for cyclic role inheritance:",
+		TestFunc: func() {
+			roleParents := map[string][]string{
+				"manager": {"staff"},
+				"staff":   {"manager"},
+			}
+			_, err := ResolveRoleHierarchy([]string{"manager"}, roleParents)
+			mctest.AssertEquals(t, err != nil, true, "cyclic inheritance error expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should merge role-services as union, with deny-overrides-allow:",
+		TestFunc: func() {
+			services := MergeRoleServices([]RoleServiceType{
+				{ServiceId: "table-1", RoleId: "staff", CanRead: true},
+				{ServiceId: "table-1", RoleId: "manager", CanUpdate: true, CanDelete: true},
+				{ServiceId: "table-1", RoleId: "auditor", CanDelete: true, IsDeny: true},
+				{ServiceId: "table-2", RoleId: "staff", CanRead: true, CanCreate: true},
+				{ServiceId: "table-2", RoleId: "guest", CanCrud: true, IsDeny: true},
+			})
+			mctest.AssertEquals(t, len(services), 1, "one effective service expected, the denied table-2 excluded")
+			mctest.AssertEquals(t, services[0].ServiceId, "table-1", "table-1 effective service expected")
+			mctest.AssertEquals(t, services[0].CanRead, true, "table-1 read should be permitted")
+			mctest.AssertEquals(t, services[0].CanUpdate, true, "table-1 update should be permitted")
+			mctest.AssertEquals(t, services[0].CanDelete, false, "table-1 delete should be denied")
+			mctest.AssertEquals(t, services[0].RoleIds, []string{"staff", "manager"}, "table-1 allow roles expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should exclude the deny-only role-services, from the permitted service items:",
+		TestFunc: func() {
+			services := MergeRoleServices([]RoleServiceType{
+				{ServiceId: "rec-1", RoleId: "staff", CanRead: true},
+				{ServiceId: "rec-2", RoleId: "auditor", CanDelete: true, IsDeny: true},
+				{ServiceId: "rec-3", RoleId: "guest", CanCrud: true, IsDeny: true},
+			})
+			mctest.AssertEquals(t, RoleServiceIds(services), []string{"rec-1"}, "permitted service items only expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the distinct role-services cache keys, by the roles and the role tables:",
+		TestFunc: func() {
+			crud := &Crud{CrudOptionsType: CrudOptionsType{RoleTable: "roles"}}
+			key := crud.roleCacheKey("user-1", []string{"staff", "manager"}, []string{"table-2", "table-1"})
+			mctest.AssertEquals(t, crud.roleCacheKey("user-1", []string{"manager", "staff"}, []string{"table-1", "table-2"}), key, "same key expected, of the unordered roles and services")
+			mctest.AssertNotEquals(t, crud.roleCacheKey("user-1", []string{"staff"}, []string{"table-1", "table-2"}), key, "distinct key expected, of the changed roles")
+			otherCrud := &Crud{CrudOptionsType: CrudOptionsType{RoleTable: "tenant_roles"}}
+			mctest.AssertNotEquals(t, otherCrud.roleCacheKey("user-1", []string{"staff", "manager"}, []string{"table-1", "table-2"}), key, "distinct key expected, of the role table")
+			hierarchyCrud := &Crud{CrudOptionsType: CrudOptionsType{RoleTable: "roles", RoleHierarchy: true, RoleInheritTable: "role_inherits"}}
+			mctest.AssertNotEquals(t, hierarchyCrud.roleCacheKey("user-1", []string{"staff", "manager"}, []string{"table-1", "table-2"}), key, "distinct key expected, of the role hierarchy")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should not share the cached role-services of the cruds without and with the field access:",
+		TestFunc: func() {
+			crud := &Crud{CrudOptionsType: CrudOptionsType{RoleTable: "roles"}}
+			fieldCrud := &Crud{CrudOptionsType: CrudOptionsType{RoleTable: "roles", CheckFieldAccess: true}}
+			cached := []RoleServiceType{{ServiceId: "table-1", RoleId: "staff", CanRead: true}}
+			roleCacheMutex.Lock()
+			roleCache[crud.roleCacheKey("user-1", []string{"staff"}, []string{"table-1"})] = roleCacheItem{roleServices: cached, expire: time.Now().Add(time.Minute)}
+			roleCacheMutex.Unlock()
+			services, err := crud.GetEffectiveRoleServices("user-1", []string{"staff"}, []string{"table-1"})
+			mctest.AssertEquals(t, err, nil, "no error expected")
+			mctest.AssertEquals(t, services, cached, "cached role-services, without the field permissions, expected")
+			roleCacheMutex.RLock()
+			_, ok := roleCache[fieldCrud.roleCacheKey("user-1", []string{"staff"}, []string{"table-1"})]
+			roleCacheMutex.RUnlock()
+			mctest.AssertEquals(t, ok, false, "no cached role-services expected, of the field access crud")
+			hierarchyCrud := &Crud{CrudOptionsType: CrudOptionsType{RoleTable: "roles", RoleHierarchy: true}}
+			mctest.AssertNotEquals(t, hierarchyCrud.roleCacheKey("user-1", []string{"staff"}, []string{"table-1"}), crud.roleCacheKey("user-1", []string{"staff"}, []string{"table-1"}), "distinct key expected, of the deny role-services")
+			InvalidateRoleCache("user-1")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return cached effective role-services until invalidated:",
+		TestFunc: func() {
+			crud := &Crud{}
+			cached := []RoleServiceType{{ServiceId: "table-1", RoleId: "staff", CanRead: true}}
+			roleCacheMutex.Lock()
+			roleCache[crud.roleCacheKey("user-1", []string{"staff"}, []string{"table-1"})] = roleCacheItem{roleServices: cached, expire: time.Now().Add(time.Minute)}
+			roleCacheMutex.Unlock()
+			services, err := crud.GetEffectiveRoleServices("user-1", []string{"staff"}, []string{"table-1"})
+			mctest.AssertEquals(t, err, nil, "no error expected")
+			mctest.AssertEquals(t, services, cached, "cached role-services expected")
+			InvalidateRoleCache("user-1")
+			roleCacheMutex.RLock()
+			_, ok := roleCache[crud.roleCacheKey("user-1", []string{"staff"}, []string{"table-1"})]
+			roleCacheMutex.RUnlock()
+			mctest.AssertEquals(t, ok, false, "role-services cache should be invalidated")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -6,13 +6,14 @@ package mccrud
 
 import (
 	"fmt"
-	"github.com/abbeymart/mccache"
 	"github.com/abbeymart/mcresponse"
 	"log"
 )
 
 // Create method creates new record(s)
 func (crud *Crud) Create(recs ActionParamsType) mcresponse.ResponseMessage {
+	// stamp app/tenant (app_id), in multi-tenant mode
+	crud.stampTenant(recs)
 	// compute query
 	createQueryRes := ComputeCreateQuery(crud.TableName, recs)
 	if !createQueryRes.Ok {
@@ -61,7 +62,7 @@ func (crud *Crud) Create(recs ActionParamsType) mcresponse.ResponseMessage {
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.CacheKey, "key")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -70,7 +71,7 @@ func (crud *Crud) Create(recs ActionParamsType) mcresponse.ResponseMessage {
 		//jVal, _ := json.Marshal(crud.ActionParams)
 		auditInfo := AuditLogOptionsType{
 			TableName:  crud.TableName,
-			LogRecords: LogRecordsType{LogRecords: crud.ActionParams},
+			LogRecords: LogRecordsType{LogRecords: crud.ActionParams, RecordIds: insertIds},
 		}
 		if logRes, logErr = crud.TransLog.AuditLog(CreateTask, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
@@ -95,11 +96,11 @@ func (crud *Crud) Update(recs ActionParamsType) mcresponse.ResponseMessage {
 	// include audit-log feature
 	if crud.LogUpdate || crud.LogCrud {
 		getRes := crud.GetByIds()
-		value, _ := getRes.Value.(CrudResultType)
+		value, _ := getRes.Value.(GetResultType)
 		crud.CurrentRecords = value.Records
 	}
 	// create from updatedRecs (actionParams)
-	updateQueryRes := ComputeUpdateQuery(crud.TableName, recs)
+	updateQueryRes := ComputeUpdateQuery(crud.TableName, recs, crud.TenantId())
 	if !updateQueryRes.Ok {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
 			Message: updateQueryRes.Message,
@@ -142,7 +143,7 @@ func (crud *Crud) Update(recs ActionParamsType) mcresponse.ResponseMessage {
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.CacheKey, "key")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -155,6 +156,7 @@ func (crud *Crud) Update(recs ActionParamsType) mcresponse.ResponseMessage {
 			TableName:     crud.TableName,
 			LogRecords:    LogRecordsType{LogRecords: crud.CurrentRecords},
 			NewLogRecords: LogRecordsType{LogRecords: crud.ActionParams},
+			LogDiff:       crud.updateLogDiffs(recs),
 		}
 		if logRes, logErr = crud.TransLog.AuditLog(UpdateTask, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
@@ -180,11 +182,11 @@ func (crud *Crud) UpdateById(rec ActionParamType, id string) mcresponse.Response
 	// include audit-log feature
 	if crud.LogUpdate || crud.LogCrud {
 		getRes := crud.GetById(id)
-		value, _ := getRes.Value.(CrudResultType)
+		value, _ := getRes.Value.(GetResultType)
 		crud.CurrentRecords = value.Records
 	}
 	// create from updatedRecs (actionParams)
-	updateQueryRes := ComputeUpdateQueryById(crud.TableName, rec, id)
+	updateQueryRes := ComputeUpdateQueryById(crud.TableName, rec, id, crud.TenantId())
 	if !updateQueryRes.Ok {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
 			Message: updateQueryRes.Message,
@@ -222,7 +224,7 @@ func (crud *Crud) UpdateById(rec ActionParamType, id string) mcresponse.Response
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.CacheKey, "key")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -235,6 +237,7 @@ func (crud *Crud) UpdateById(rec ActionParamType, id string) mcresponse.Response
 			TableName:     crud.TableName,
 			LogRecords:    LogRecordsType{LogRecords: crud.CurrentRecords},
 			NewLogRecords: LogRecordsType{LogRecords: crud.ActionParams},
+			LogDiff:       crud.updateLogDiffs(ActionParamsType{rec}),
 		}
 		if logRes, logErr = crud.TransLog.AuditLog(UpdateTask, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
@@ -261,11 +264,11 @@ func (crud *Crud) UpdateByIds(rec ActionParamType) mcresponse.ResponseMessage {
 	// include audit-log feature
 	if crud.LogUpdate || crud.LogCrud {
 		getRes := crud.GetByIds()
-		value, _ := getRes.Value.(CrudResultType)
+		value, _ := getRes.Value.(GetResultType)
 		crud.CurrentRecords = value.Records
 	}
 	// create from updatedRecs (actionParams)
-	updateQueryRes := ComputeUpdateQueryByIds(crud.TableName, rec, crud.RecordIds)
+	updateQueryRes := ComputeUpdateQueryByIds(crud.TableName, rec, crud.RecordIds, crud.TenantId())
 	if !updateQueryRes.Ok {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
 			Message: updateQueryRes.Message,
@@ -311,7 +314,7 @@ func (crud *Crud) UpdateByIds(rec ActionParamType) mcresponse.ResponseMessage {
 	//		updateCount += len(crud.RecordIds)
 	//	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.CacheKey, "key")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -324,6 +327,7 @@ func (crud *Crud) UpdateByIds(rec ActionParamType) mcresponse.ResponseMessage {
 			TableName:     crud.TableName,
 			LogRecords:    LogRecordsType{LogRecords: crud.CurrentRecords},
 			NewLogRecords: LogRecordsType{LogRecords: crud.ActionParams},
+			LogDiff:       crud.updateLogDiffs(ActionParamsType{rec}),
 		}
 		if logRes, logErr = crud.TransLog.AuditLog(UpdateTask, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
@@ -349,11 +353,11 @@ func (crud *Crud) UpdateByParam(rec ActionParamType) mcresponse.ResponseMessage
 	// include audit-log feature
 	if crud.LogUpdate || crud.LogCrud {
 		getRes := crud.GetByParam()
-		value, _ := getRes.Value.(CrudResultType)
+		value, _ := getRes.Value.(GetResultType)
 		crud.CurrentRecords = value.Records
 	}
 	// create from updatedRecs (actionParams)
-	updateQueryRes := ComputeUpdateQueryByParam(crud.TableName, rec, crud.QueryParams)
+	updateQueryRes := ComputeUpdateQueryByParam(crud.TableName, rec, crud.QueryParams, crud.TenantId())
 	if !updateQueryRes.Ok {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
 			Message: updateQueryRes.Message,
@@ -392,7 +396,7 @@ func (crud *Crud) UpdateByParam(rec ActionParamType) mcresponse.ResponseMessage
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.TableName, crud.CacheKey, "key")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -405,6 +409,7 @@ func (crud *Crud) UpdateByParam(rec ActionParamType) mcresponse.ResponseMessage
 			TableName:     crud.TableName,
 			LogRecords:    LogRecordsType{LogRecords: crud.CurrentRecords},
 			NewLogRecords: LogRecordsType{LogRecords: crud.ActionParams},
+			LogDiff:       crud.updateLogDiffs(ActionParamsType{rec}),
 		}
 		if logRes, logErr = crud.TransLog.AuditLog(UpdateTask, crud.UserInfo.UserId, auditInfo); logErr != nil {
 			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
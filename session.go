@@ -0,0 +1,305 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: session and access-token management: login, logout, refresh and revoke
+
+package mccrud
+
+import (
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"time"
+)
+
+// LoginParamsType is the login credentials, loginName may be the email or username
+type LoginParamsType struct {
+	LoginName string `json:"loginName"`
+	Password  string `json:"password"`
+}
+
+// SessionType is the Login and RefreshToken response value. Expire values are in milliseconds.
+type SessionType struct {
+	UserId        string `json:"userId"`
+	LoginName     string `json:"loginName"`
+	Token         string `json:"token"`
+	Expire        int64  `json:"expire"`
+	RefreshToken  string `json:"refreshToken"`
+	RefreshExpire int64  `json:"refreshExpire"`
+}
+
+// newSession computes new access and refresh tokens, and the expiry (milliseconds, from now), for the user
+func (crud *Crud) newSession(userId string, loginName string, now time.Time) (SessionType, error) {
+	token, err := GenerateToken(32)
+	if err != nil {
+		return SessionType{}, err
+	}
+	refreshToken, err := GenerateToken(32)
+	if err != nil {
+		return SessionType{}, err
+	}
+	return SessionType{
+		UserId:        userId,
+		LoginName:     loginName,
+		Token:         token,
+		Expire:        now.Add(time.Duration(crud.LoginTimeout)*time.Second).Unix() * 1000,
+		RefreshToken:  refreshToken,
+		RefreshExpire: now.Add(time.Duration(crud.RefreshTimeout)*time.Second).Unix() * 1000,
+	}, nil
+}
+
+// sessionExpired returns the expired status of the token expire (milliseconds), at the specified time
+func sessionExpired(expire int64, now time.Time) bool {
+	return (now.Unix() * 1000) > expire
+}
+
+// Login method validates the user credentials and creates a new session (access-token) in the access-table
+func (crud *Crud) Login(params LoginParamsType) mcresponse.ResponseMessage {
+	if params.LoginName == "" || params.Password == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "loginName and password are required",
+			Value:   nil,
+		})
+	}
+	// get user-information by email or username
+	emailUsername := EmailUsername(params.LoginName)
+	var (
+		userId   string
+		password string
+		isActive bool
+	)
+	loginField := "username"
+	loginValue := emailUsername.Username
+	if emailUsername.Email != "" {
+		loginField = "email"
+		loginValue = emailUsername.Email
+	}
+	userScript := fmt.Sprintf("SELECT id, password, is_active from %v WHERE %v=$1", crud.UserTable, loginField)
+	rowUser := crud.AccessDb.QueryRow(userScript, loginValue)
+	// invalid loginName and password responses (and response times) are indistinguishable: the password of the
+	// unknown loginName is compared with the dummy password hash
+	scanErr := rowUser.Scan(&userId, &password, &isActive)
+	if scanErr != nil {
+		password = dummyPasswordHash(crud.PasswordHasher)
+	}
+	if passwordErr := crud.PasswordHasher.ComparePassword(password, params.Password); scanErr != nil || passwordErr != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Invalid loginName or password",
+			Value:   nil,
+		})
+	}
+	if !isActive {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Account is not active. Validate active status",
+			Value:   nil,
+		})
+	}
+	// create session
+	session, err := crud.newSession(userId, params.LoginName, time.Now())
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating access-token: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	accessScript := fmt.Sprintf("INSERT INTO %v(user_id, login_name, token, expire, refresh_token, refresh_expire, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)", crud.AccessTable)
+	if _, err = crud.AccessDb.Exec(accessScript, session.UserId, session.LoginName, session.Token, session.Expire, HashToken(session.RefreshToken), session.RefreshExpire, time.Now()); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating access-token: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// set the current user-information
+	crud.UserInfo.UserId = session.UserId
+	crud.UserInfo.LoginName = session.LoginName
+	crud.UserInfo.Token = session.Token
+	crud.UserInfo.Expire = session.Expire
+	// perform audit-log
+	if crud.LogLogin || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(LoginLog, session.UserId, AuditLogOptionsType{
+			TableName: crud.UserTable,
+			LogRecords: map[string]interface{}{
+				"userId":    session.UserId,
+				"loginName": session.LoginName,
+				"expire":    session.Expire,
+			},
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Login completed successfully",
+		Value:   session,
+	})
+}
+
+// Logout method removes the current session (UserInfo access-token) from the access-table
+func (crud *Crud) Logout() mcresponse.ResponseMessage {
+	if crud.UserInfo.UserId == "" || crud.UserInfo.Token == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "userId and token are required",
+			Value:   nil,
+		})
+	}
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1 AND token=$2", crud.AccessTable)
+	res, err := crud.AccessDb.Exec(delScript, crud.UserInfo.UserId, crud.UserInfo.Token)
+	if err != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error removing access-token: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	InvalidateAccessCache(crud.UserInfo.Token)
+	rowsCount, _ := res.RowsAffected()
+	if rowsCount < 1 {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: "Session not found or already logged-out",
+			Value:   nil,
+		})
+	}
+	// perform audit-log
+	if crud.LogLogout || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(LogoutLog, crud.UserInfo.UserId, AuditLogOptionsType{
+			TableName: crud.UserTable,
+			LogRecords: map[string]interface{}{
+				"userId":    crud.UserInfo.UserId,
+				"loginName": crud.UserInfo.LoginName,
+			},
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Logout completed successfully",
+		Value:   nil,
+	})
+}
+
+// RefreshToken method replaces the session (access and refresh tokens) of a valid refresh-token
+func (crud *Crud) RefreshToken(refreshToken string) mcresponse.ResponseMessage {
+	if refreshToken == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "refresh-token is required",
+			Value:   nil,
+		})
+	}
+	var (
+		userId        string
+		loginName     string
+		refreshExpire int64
+		isActive      bool
+	)
+	refreshHash := HashToken(refreshToken)
+	accessScript := fmt.Sprintf("SELECT user_id, login_name, refresh_expire from %v WHERE refresh_token=$1", crud.AccessTable)
+	rowAccess := crud.AccessDb.QueryRow(accessScript, refreshHash)
+	if err := rowAccess.Scan(&userId, &loginName, &refreshExpire); err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: invalid or revoked refresh-token",
+			Value:   nil,
+		})
+	}
+	if sessionExpired(refreshExpire, time.Now()) {
+		delScript := fmt.Sprintf("DELETE FROM %v WHERE refresh_token=$1", crud.AccessTable)
+		_, _ = crud.AccessDb.Exec(delScript, refreshHash)
+		return mcresponse.GetResMessage("tokenExpired", mcresponse.ResponseMessageOptions{
+			Message: "Refresh-token expired: please login to continue",
+			Value:   nil,
+		})
+	}
+	// user must still be active
+	userScript := fmt.Sprintf("SELECT is_active from %v WHERE id=$1", crud.UserTable)
+	if err := crud.AccessDb.QueryRow(userScript, userId).Scan(&isActive); err != nil || !isActive {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: user information not found or is inactive",
+			Value:   nil,
+		})
+	}
+	// rotate the access and refresh tokens
+	InvalidateUserAccessCache(userId)
+	session, err := crud.newSession(userId, loginName, time.Now())
+	if err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error refreshing access-token: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	updateScript := fmt.Sprintf("UPDATE %v SET token=$1, expire=$2, refresh_token=$3, refresh_expire=$4 WHERE refresh_token=$5", crud.AccessTable)
+	res, err := crud.AccessDb.Exec(updateScript, session.Token, session.Expire, HashToken(session.RefreshToken), session.RefreshExpire, refreshHash)
+	if err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error refreshing access-token: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// the refresh-token may have been used by a concurrent request
+	if rowsCount, _ := res.RowsAffected(); rowsCount < 1 {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: invalid or revoked refresh-token",
+			Value:   nil,
+		})
+	}
+	crud.UserInfo.UserId = session.UserId
+	crud.UserInfo.LoginName = session.LoginName
+	crud.UserInfo.Token = session.Token
+	crud.UserInfo.Expire = session.Expire
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Access-token refreshed successfully",
+		Value:   session,
+	})
+}
+
+// revokeSessionsAccess method authenticates the current user, and returns the access information of the user
+// permitted to revoke the sessions of the userId: the same (authenticated) user, or admin
+func (crud *Crud) revokeSessionsAccess(userId string) mcresponse.ResponseMessage {
+	accessRes := crud.CheckUserAccess()
+	if accessRes.Code != "success" {
+		return accessRes
+	}
+	accessInfo, ok := accessRes.Value.(AccessInfoType)
+	if !ok || accessInfo.UserId == "" {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Error parsing user access information/value",
+			Value:   nil,
+		})
+	}
+	if accessInfo.UserId != userId && !accessInfo.IsAdmin {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "You are not authorized to revoke the sessions of other users",
+			Value:   nil,
+		})
+	}
+	return accessRes
+}
+
+// RevokeAllSessions method removes all the sessions of the user. Other users' sessions may be revoked by admin only.
+func (crud *Crud) RevokeAllSessions(userId string) mcresponse.ResponseMessage {
+	if userId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "userId is required",
+			Value:   nil,
+		})
+	}
+	accessRes := crud.revokeSessionsAccess(userId)
+	if accessRes.Code != "success" {
+		return accessRes
+	}
+	accessInfo := accessRes.Value.(AccessInfoType)
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1", crud.AccessTable)
+	res, err := crud.AccessDb.Exec(delScript, userId)
+	if err != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error revoking sessions: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	InvalidateUserAccessCache(userId)
+	rowsCount, _ := res.RowsAffected()
+	// perform audit-log
+	if crud.LogLogout || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(LogoutLog, accessInfo.UserId, AuditLogOptionsType{
+			TableName: crud.UserTable,
+			LogRecords: map[string]interface{}{
+				"userId":          userId,
+				"revokedSessions": rowsCount,
+			},
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v session(s) revoked successfully", rowsCount),
+		Value:   rowsCount,
+	})
+}
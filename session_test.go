@@ -0,0 +1,105 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: session and access-token management test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+	"time"
+)
+
+// countingPasswordHasher counts the password comparisons, of the bcrypt hasher
+type countingPasswordHasher struct {
+	*BcryptPasswordHasher
+	compares int
+}
+
+// ComparePassword method counts and performs the password comparison
+func (hasher *countingPasswordHasher) ComparePassword(hashedPassword string, password string) error {
+	hasher.compares++
+	return hasher.BcryptPasswordHasher.ComparePassword(hashedPassword, password)
+}
+
+func TestSession(t *testing.T) {
+	secret := []byte("test-secret")
+	auth := NewJwtAuthenticator(secret, JwtAuthenticator{})
+	userToken := func(userId string, isAdmin bool) UserInfoType {
+		token, _ := SignJwtToken(map[string]interface{}{
+			"sub":     userId,
+			"isAdmin": isAdmin,
+			"exp":     time.Now().Add(time.Hour).Unix(),
+		}, secret, "HS256")
+		return UserInfoType{UserId: userId, Token: token}
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute unique session tokens and the login and refresh expiry:",
+		TestFunc: func() {
+			crud := Crud{CrudOptionsType: CrudOptionsType{LoginTimeout: 3600, RefreshTimeout: 604800}}
+			now := time.Unix(1700000000, 0)
+			session, err := crud.newSession("user-1", "abbeymart", now)
+			mctest.AssertEquals(t, err, nil, "session error should be: nil")
+			mctest.AssertEquals(t, session.UserId, "user-1", "session userId expected")
+			mctest.AssertEquals(t, session.Expire, int64(1700003600000), "access-token expire (ms) expected")
+			mctest.AssertEquals(t, session.RefreshExpire, int64(1700604800000), "refresh-token expire (ms) expected")
+			mctest.AssertEquals(t, len(session.Token), 64, "hex access-token expected")
+			mctest.AssertEquals(t, session.Token != session.RefreshToken, true, "distinct access and refresh tokens expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should determine the token expiry, in milliseconds:",
+		TestFunc: func() {
+			now := time.Unix(1700000000, 0)
+			mctest.AssertEquals(t, sessionExpired(1700000001000, now), false, "unexpired token expected")
+			mctest.AssertEquals(t, sessionExpired(1700000000000, now), false, "token expiring now should be unexpired")
+			mctest.AssertEquals(t, sessionExpired(1699999999000, now), true, "expired token expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should require the login, logout and refresh parameters:",
+		TestFunc: func() {
+			crud := Crud{}
+			mctest.AssertEquals(t, crud.Login(LoginParamsType{LoginName: "abbeymart"}).Code, "paramsError", "login password required")
+			mctest.AssertEquals(t, crud.Logout().Code, "paramsError", "logout userId and token required")
+			mctest.AssertEquals(t, crud.RefreshToken("").Code, "paramsError", "refresh-token required")
+			mctest.AssertEquals(t, crud.RevokeAllSessions("").Code, "paramsError", "revoke userId required")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should authenticate the revoke-sessions caller, of the same user or admin:",
+		TestFunc: func() {
+			crud := Crud{CrudOptionsType: CrudOptionsType{Authenticator: auth}}
+			// the unauthenticated userId must not grant the user sessions revoke
+			crud.UserInfo = UserInfoType{UserId: "user-2", Token: "invalid-token"}
+			mctest.AssertEquals(t, crud.RevokeAllSessions("user-2").Code, "unAuthorized", "unauthenticated caller denied")
+			crud.UserInfo = userToken("user-1", false)
+			mctest.AssertEquals(t, crud.RevokeAllSessions("user-2").Code, "unAuthorized", "non-admin caller of other user denied")
+			res := crud.revokeSessionsAccess("user-1")
+			mctest.AssertEquals(t, res.Code, "success", "same-user caller permitted")
+			mctest.AssertEquals(t, res.Value.(AccessInfoType).UserId, "user-1", "authenticated caller expected")
+			crud.UserInfo = userToken("admin-1", true)
+			mctest.AssertEquals(t, crud.revokeSessionsAccess("user-2").Code, "success", "admin caller permitted")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the cached dummy password hash, of the unknown-user logins, by the hasher:",
+		TestFunc: func() {
+			hasher := &countingPasswordHasher{BcryptPasswordHasher: NewBcryptPasswordHasher(0)}
+			hash := dummyPasswordHash(hasher)
+			mctest.AssertNotEquals(t, hash, "", "dummy password hash expected")
+			mctest.AssertEquals(t, dummyPasswordHash(hasher), hash, "cached dummy password hash expected")
+			mctest.AssertNotEquals(t, hasher.ComparePassword(hash, "password"), nil, "dummy password hash mismatch expected")
+			mctest.AssertEquals(t, hasher.compares, 1, "one password comparison expected")
+			otherHash := dummyPasswordHash(NewBcryptPasswordHasher(12))
+			mctest.AssertNotEquals(t, otherHash, hash, "dummy password hash, of the hasher cost, expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
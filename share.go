@@ -0,0 +1,377 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: per-record sharing (ACL) with users/groups, and records ownership transfer
+
+package mccrud
+
+import (
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+// Share grantee types, group grantees are matched by the user roleId/roleIds
+const (
+	ShareUserGrantee  = "user"
+	ShareGroupGrantee = "group"
+)
+
+// ShareType is the record share/grant, from the share table
+type ShareType struct {
+	Id          string    `json:"id" db:"id"`
+	TableName   string    `json:"tableName" db:"table_name"`
+	RecordId    string    `json:"recordId" db:"record_id"`
+	GranteeType string    `json:"granteeType" db:"grantee_type"`
+	GranteeId   string    `json:"granteeId" db:"grantee_id"`
+	CanRead     bool      `json:"canRead" db:"can_read"`
+	CanWrite    bool      `json:"canWrite" db:"can_write"` // update, includes read
+	GrantedBy   string    `json:"grantedBy" db:"granted_by"`
+	GrantedAt   time.Time `json:"grantedAt" db:"granted_at"`
+}
+
+// ShareParamsType is the share/grant request, for the crud table records
+type ShareParamsType struct {
+	RecordIds   []string `json:"recordIds"`
+	GranteeType string   `json:"granteeType"`
+	GranteeId   string   `json:"granteeId"`
+	CanRead     bool     `json:"canRead"`
+	CanWrite    bool     `json:"canWrite"`
+}
+
+// ValidateShareParams function validates the share request information
+func ValidateShareParams(params ShareParamsType) MessageObject {
+	errMsg := MessageObject{}
+	if len(params.RecordIds) < 1 {
+		errMsg["recordIds"] = "recordIds are required"
+	}
+	if params.GranteeType != ShareUserGrantee && params.GranteeType != ShareGroupGrantee {
+		errMsg["granteeType"] = fmt.Sprintf("granteeType must be %v or %v", ShareUserGrantee, ShareGroupGrantee)
+	}
+	if params.GranteeId == "" {
+		errMsg["granteeId"] = "granteeId is required"
+	}
+	return errMsg
+}
+
+// SharePermitted function determines if the shares permit the taskType (read, update) on all the recordIds
+func SharePermitted(shares []ShareType, taskType string, recordIds []string) bool {
+	if len(recordIds) < 1 {
+		return false
+	}
+	var permittedIds []string
+	for _, share := range shares {
+		switch taskType {
+		case ReadTask:
+			if share.CanRead || share.CanWrite {
+				permittedIds = append(permittedIds, share.RecordId)
+			}
+		case UpdateTask:
+			if share.CanWrite {
+				permittedIds = append(permittedIds, share.RecordId)
+			}
+		}
+	}
+	return len(MissingRecordIds(recordIds, permittedIds)) == 0
+}
+
+// ShareRecordIds function returns the (shared) record ids of the shares
+func ShareRecordIds(shares []ShareType) []string {
+	var recordIds []string
+	for _, share := range shares {
+		if !ArrayStringContains(recordIds, share.RecordId) {
+			recordIds = append(recordIds, share.RecordId)
+		}
+	}
+	return recordIds
+}
+
+// scanShares returns the shares from the share-table query rows
+func scanShares(rows *sqlx.Rows) []ShareType {
+	var shares []ShareType
+	for rows.Next() {
+		var share ShareType
+		if err := rows.StructScan(&share); err == nil {
+			shares = append(shares, share)
+		}
+	}
+	return shares
+}
+
+// GetUserShares method returns the shares of the crud-table records (recordIds) granted to the user or the
+// user groups (roleIds)
+func (crud *Crud) GetUserShares(userId string, groupIds []string, recordIds []string) ([]ShareType, error) {
+	if len(recordIds) < 1 || userId == "" {
+		return nil, nil
+	}
+	granteeQuery := "(grantee_type=$2 AND grantee_id=$3)"
+	var groups []string
+	for _, groupId := range groupIds {
+		if groupId != "" && !ArrayStringContains(groups, groupId) {
+			groups = append(groups, groupId)
+		}
+	}
+	if len(groups) > 0 {
+		granteeQuery = fmt.Sprintf("(%v OR (grantee_type=$4 AND grantee_id IN (%v)))", granteeQuery, ArrayToSQLStringValues(groups))
+	}
+	shareScript := fmt.Sprintf("SELECT id, table_name, record_id, grantee_type, grantee_id, can_read, can_write, granted_by, granted_at FROM %v WHERE table_name=$1 AND record_id IN (%v) AND %v", crud.ShareTable, ArrayToSQLStringValues(recordIds), granteeQuery)
+	shareValues := []interface{}{crud.TableName, ShareUserGrantee, userId}
+	if len(groups) > 0 {
+		shareValues = append(shareValues, ShareGroupGrantee)
+	}
+	rows, err := crud.AccessDb.Queryx(shareScript, shareValues...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+	return scanShares(rows), nil
+}
+
+// CheckRecordsOwner method determines if the current user is admin or owns (created_by) all the recordIds
+func (crud *Crud) CheckRecordsOwner(recordIds []string) mcresponse.ResponseMessage {
+	accessRes := crud.CheckUserAccess()
+	if accessRes.Code != "success" {
+		return accessRes
+	}
+	accessInfo, ok := accessRes.Value.(AccessInfoType)
+	if !ok {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Error parsing user access information/value",
+			Value:   nil,
+		})
+	}
+	if !accessInfo.IsActive {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Account is not active. Validate active status",
+			Value:   nil,
+		})
+	}
+	if accessInfo.IsAdmin {
+		return accessRes
+	}
+	ownerWhere, ownerValues := crud.TenantWhere(fmt.Sprintf("WHERE id IN (%v) AND created_by=$1", ArrayToSQLStringValues(recordIds)), []interface{}{accessInfo.UserId})
+	ownerScript := fmt.Sprintf("SELECT id FROM %v %v", crud.TableName, ownerWhere)
+	rows, err := crud.AppDb.Queryx(ownerScript, ownerValues...)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+	var ownedIds []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err == nil {
+			ownedIds = append(ownedIds, id)
+		}
+	}
+	if missingIds := MissingRecordIds(recordIds, ownedIds); len(missingIds) > 0 {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Only the records owner or admin can share or transfer the records",
+			Value:   missingIds,
+		})
+	}
+	return accessRes
+}
+
+// GrantShare method shares the crud-table records with the user/group grantee, replacing the existing grant
+func (crud *Crud) GrantShare(params ShareParamsType) mcresponse.ResponseMessage {
+	if errMsg := ValidateShareParams(params); len(errMsg) > 0 {
+		return GetParamsMessage(errMsg)
+	}
+	if !params.CanRead && !params.CanWrite {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "canRead or canWrite permission is required, use RevokeShare to remove the grant",
+			Value:   nil,
+		})
+	}
+	if ownerRes := crud.CheckRecordsOwner(params.RecordIds); ownerRes.Code != "success" {
+		return ownerRes
+	}
+	tx, err := crud.AccessDb.Beginx()
+	if err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error starting share transaction: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE table_name=$1 AND record_id=$2 AND grantee_type=$3 AND grantee_id=$4", crud.ShareTable)
+	insertScript := fmt.Sprintf("INSERT INTO %v(table_name, record_id, grantee_type, grantee_id, can_read, can_write, granted_by, granted_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)", crud.ShareTable)
+	grantedAt := time.Now()
+	var shares []ShareType
+	for _, recordId := range params.RecordIds {
+		if _, err = tx.Exec(delScript, crud.TableName, recordId, params.GranteeType, params.GranteeId); err == nil {
+			_, err = tx.Exec(insertScript, crud.TableName, recordId, params.GranteeType, params.GranteeId, params.CanRead, params.CanWrite, crud.UserInfo.UserId, grantedAt)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error sharing record(s): %v", err.Error()),
+				Value:   nil,
+			})
+		}
+		shares = append(shares, ShareType{
+			TableName:   crud.TableName,
+			RecordId:    recordId,
+			GranteeType: params.GranteeType,
+			GranteeId:   params.GranteeId,
+			CanRead:     params.CanRead,
+			CanWrite:    params.CanWrite,
+			GrantedBy:   crud.UserInfo.UserId,
+			GrantedAt:   grantedAt,
+		})
+	}
+	if err = tx.Commit(); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error sharing record(s): %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	// perform audit-log
+	if crud.LogCreate || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(CreateLog, crud.UserInfo.UserId, AuditLogOptionsType{
+			TableName:  crud.ShareTable,
+			LogRecords: shares,
+			RecordIds:  params.RecordIds,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v record(s) shared with %v %v", len(shares), params.GranteeType, params.GranteeId),
+		Value:   shares,
+	})
+}
+
+// RevokeShare method removes the grant of the crud-table records, to the user/group grantee
+func (crud *Crud) RevokeShare(params ShareParamsType) mcresponse.ResponseMessage {
+	if errMsg := ValidateShareParams(params); len(errMsg) > 0 {
+		return GetParamsMessage(errMsg)
+	}
+	if ownerRes := crud.CheckRecordsOwner(params.RecordIds); ownerRes.Code != "success" {
+		return ownerRes
+	}
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE table_name=$1 AND record_id IN (%v) AND grantee_type=$2 AND grantee_id=$3", crud.ShareTable, ArrayToSQLStringValues(params.RecordIds))
+	res, err := crud.AccessDb.Exec(delScript, crud.TableName, params.GranteeType, params.GranteeId)
+	if err != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error revoking record share(s): %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	rowsCount, _ := res.RowsAffected()
+	// perform audit-log
+	if crud.LogDelete || crud.LogCrud {
+		_, _ = crud.TransLog.AuditLog(DeleteLog, crud.UserInfo.UserId, AuditLogOptionsType{
+			TableName:  crud.ShareTable,
+			LogRecords: params,
+			RecordIds:  params.RecordIds,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v record share(s) revoked successfully", rowsCount),
+		Value:   rowsCount,
+	})
+}
+
+// ListShares method returns all the shares/grants of the crud-table records, for the records owner or admin
+func (crud *Crud) ListShares(recordIds []string) mcresponse.ResponseMessage {
+	if len(recordIds) < 1 {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "recordIds are required",
+			Value:   nil,
+		})
+	}
+	if ownerRes := crud.CheckRecordsOwner(recordIds); ownerRes.Code != "success" {
+		return ownerRes
+	}
+	shareScript := fmt.Sprintf("SELECT id, table_name, record_id, grantee_type, grantee_id, can_read, can_write, granted_by, granted_at FROM %v WHERE table_name=$1 AND record_id IN (%v) ORDER BY record_id, granted_at", crud.ShareTable, ArrayToSQLStringValues(recordIds))
+	rows, err := crud.AccessDb.Queryx(shareScript, crud.TableName)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+	shares := scanShares(rows)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v record share(s) found", len(shares)),
+		Value:   shares,
+	})
+}
+
+// TransferOwnership method transfers the ownership (created_by) of the crud-table records to the new owner
+func (crud *Crud) TransferOwnership(recordIds []string, newOwnerId string) mcresponse.ResponseMessage {
+	if len(recordIds) < 1 || newOwnerId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "recordIds and newOwnerId are required",
+			Value:   nil,
+		})
+	}
+	if ownerRes := crud.CheckRecordsOwner(recordIds); ownerRes.Code != "success" {
+		return ownerRes
+	}
+	// current owners, for the audit-log
+	inValues := ArrayToSQLStringValues(recordIds)
+	ownerWhere, ownerValues := crud.TenantWhere(fmt.Sprintf("WHERE id IN (%v)", inValues), nil)
+	ownerScript := fmt.Sprintf("SELECT id, created_by FROM %v %v", crud.TableName, ownerWhere)
+	rows, err := crud.AppDb.Queryx(ownerScript, ownerValues...)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	var logRecords, newLogRecords []map[string]interface{}
+	for rows.Next() {
+		var id, createdBy string
+		if err := rows.Scan(&id, &createdBy); err == nil {
+			logRecords = append(logRecords, map[string]interface{}{"id": id, "createdBy": createdBy})
+			newLogRecords = append(newLogRecords, map[string]interface{}{"id": id, "createdBy": newOwnerId})
+		}
+	}
+	_ = rows.Close()
+	updateWhere, updateValues := crud.TenantWhere(fmt.Sprintf("WHERE id IN (%v)", inValues), []interface{}{newOwnerId, crud.UserInfo.UserId, time.Now()})
+	updateScript := fmt.Sprintf("UPDATE %v SET created_by=$1, updated_by=$2, updated_at=$3 %v", crud.TableName, updateWhere)
+	res, err := crud.AppDb.Exec(updateScript, updateValues...)
+	if err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error transferring record(s) ownership: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	rowsCount, _ := res.RowsAffected()
+	// delete cache, and the cached access information of the access tables
+	crud.invalidateCache()
+	if crud.AccessCache {
+		crud.invalidateAccessTableCache(crud.TableName, recordIds)
+	}
+	// perform audit-log, for all ownership transfers
+	logMessage := ""
+	logRes, logErr := crud.TransLog.AuditLog(UpdateLog, crud.UserInfo.UserId, AuditLogOptionsType{
+		TableName:     crud.TableName,
+		LogRecords:    logRecords,
+		NewLogRecords: newLogRecords,
+		RecordIds:     recordIds,
+	})
+	if logErr != nil {
+		logMessage = fmt.Sprintf(" | Audit-log-error: %v", logErr.Error())
+	} else {
+		logMessage = fmt.Sprintf(" | Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v record(s) transferred to %v%v", rowsCount, newOwnerId, logMessage),
+		Value: CrudResultType{
+			RecordIds:    recordIds,
+			RecordsCount: int(rowsCount),
+			TaskType:     UpdateTask,
+			LogRes:       logRes,
+		},
+	})
+}
@@ -0,0 +1,46 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: per-record sharing (ACL) test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestShare(t *testing.T) {
+	shares := []ShareType{
+		{RecordId: "rec-1", GranteeType: ShareUserGrantee, GranteeId: "user-2", CanRead: true},
+		{RecordId: "rec-2", GranteeType: ShareGroupGrantee, GranteeId: "editors", CanWrite: true},
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should validate the share information:",
+		TestFunc: func() {
+			errMsg := ValidateShareParams(ShareParamsType{RecordIds: []string{"rec-1"}, GranteeType: ShareUserGrantee, GranteeId: "user-2", CanRead: true})
+			mctest.AssertEquals(t, len(errMsg), 0, "no validation error expected")
+			errMsg = ValidateShareParams(ShareParamsType{GranteeType: "team"})
+			mctest.AssertEquals(t, len(errMsg), 3, "recordIds, granteeType and granteeId errors expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should permit read of the read/write shared records:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, SharePermitted(shares, ReadTask, []string{"rec-1", "rec-2"}), true, "read should be permitted")
+			mctest.AssertEquals(t, SharePermitted(shares, ReadTask, []string{"rec-1", "rec-3"}), false, "read of un-shared record should be denied")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should permit update of the write shared records only:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, SharePermitted(shares, UpdateTask, []string{"rec-2"}), true, "update should be permitted")
+			mctest.AssertEquals(t, SharePermitted(shares, UpdateTask, []string{"rec-1"}), false, "update of read shared record should be denied")
+			mctest.AssertEquals(t, SharePermitted(shares, DeleteTask, []string{"rec-2"}), false, "delete of shared record should be denied")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,85 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: multi-tenant (app_id) isolation, for multi-hosted apps environment
+
+package mccrud
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"github.com/abbeymart/mcresponse"
+)
+
+// TenantId method returns the app-id scoping the crud queries, in multi-tenant mode (empty otherwise)
+func (crud *Crud) TenantId() string {
+	if !crud.TenantMode {
+		return ""
+	}
+	return crud.AppParams.AppId
+}
+
+// TenantWhere method scopes the where-query to the crud app/tenant, in multi-tenant mode
+func (crud *Crud) TenantWhere(whereQuery string, fieldValues []interface{}) (string, []interface{}) {
+	return ComputeTenantWhere(whereQuery, fieldValues, crud.TenantId())
+}
+
+// CheckAppAccess method validates the crud AppParams (app-id and access-key) against the apps-table
+func (crud *Crud) CheckAppAccess() mcresponse.ResponseMessage {
+	if crud.AppParams.AppId == "" || crud.AppParams.AccessKey == "" {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: app-id and access-key are required",
+			Value:   nil,
+		})
+	}
+	var (
+		app      AppType
+		isActive bool
+	)
+	appScript := fmt.Sprintf("SELECT id, app_name, access_key, category, owner_id, is_active from %v WHERE id=$1", crud.AppTable)
+	appRow := crud.AccessDb.QueryRow(appScript, crud.AppParams.AppId)
+	if err := appRow.Scan(&app.Id, &app.AppName, &app.AccessKey, &app.Category, &app.OwnerId, &isActive); err != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Unauthorized: app information not found | %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	app.IsActive = isActive
+	if !app.IsActive {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: app is not active",
+			Value:   nil,
+		})
+	}
+	// constant-time comparison of the access-key
+	if subtle.ConstantTimeCompare([]byte(app.AccessKey), []byte(crud.AppParams.AccessKey)) != 1 {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: invalid app access-key",
+			Value:   nil,
+		})
+	}
+	if crud.AppParams.AppName != "" && crud.AppParams.AppName != app.AppName {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Unauthorized: app-name does not match the app-id",
+			Value:   nil,
+		})
+	}
+	// exclude access-key from the response value
+	app.AccessKey = ""
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "App access permitted.",
+		Value:   app,
+	})
+}
+
+// stampTenant method stamps the create-records with the app/tenant (appId), in multi-tenant mode. The
+// client-supplied app/tenant (appId, app_id) fields are removed.
+func (crud *Crud) stampTenant(recs ActionParamsType) {
+	if !crud.TenantMode {
+		return
+	}
+	for _, rec := range recs {
+		delete(rec, "appId")
+		delete(rec, "app_id")
+		rec["appId"] = crud.AppParams.AppId
+	}
+}
@@ -0,0 +1,207 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: multi-tenant (app_id) query scoping test cases
+
+package mccrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+// tenantModel is the single-field (deterministic select-fields) model of the tenant queries
+type tenantModel struct {
+	Id string `json:"id"`
+}
+
+func TestTenant(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should scope the where-query to the app/tenant, after the where-values:",
+		TestFunc: func() {
+			testCases := []struct {
+				name        string
+				whereQuery  string
+				fieldValues []interface{}
+				appId       string
+				query       string
+				values      []interface{}
+			}{
+				{name: "non-tenant mode", whereQuery: "WHERE id=$1", fieldValues: []interface{}{"g-1"}, query: "WHERE id=$1", values: []interface{}{"g-1"}},
+				{name: "without where-conditions", appId: "app-1", query: "WHERE app_id=$1", values: []interface{}{"app-1"}},
+				{name: "by id", whereQuery: "WHERE id=$1", fieldValues: []interface{}{"g-1"}, appId: "app-1", query: "WHERE id=$1 AND app_id=$2", values: []interface{}{"g-1", "app-1"}},
+				{name: "by literal ids", whereQuery: "WHERE id IN ('g-1', 'g-2')", appId: "app-1", query: "WHERE id IN ('g-1', 'g-2') AND app_id=$1", values: []interface{}{"app-1"}},
+			}
+			for _, testCase := range testCases {
+				query, values := ComputeTenantWhere(testCase.whereQuery, testCase.fieldValues, testCase.appId)
+				mctest.AssertEquals(t, query, testCase.query, testCase.name+": where-query expected")
+				mctest.AssertEquals(t, values, testCase.values, testCase.name+": where-values expected")
+			}
+			fieldValues := []interface{}{"g-1"}
+			_, _ = ComputeTenantWhere("WHERE id=$1", fieldValues, "app-1")
+			mctest.AssertEquals(t, fieldValues, []interface{}{"g-1"}, "unchanged source values expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the contiguous where-placeholders, of the IN-conditions:",
+		TestFunc: func() {
+			whereRes := ComputeWhereQuery(QueryParamType{"groupIds": []string{"g-1", "g-2"}}, 1)
+			mctest.AssertEquals(t, whereRes.WhereQueryObject.WhereQuery, "WHERE group_ids IN ('g-1', 'g-2')", "IN-condition expected")
+			query, values := ComputeTenantWhere(whereRes.WhereQueryObject.WhereQuery, whereRes.WhereQueryObject.FieldValues, "app-1")
+			mctest.AssertEquals(t, query, "WHERE group_ids IN ('g-1', 'g-2') AND app_id=$1", "tenant placeholder, after the IN-condition, expected")
+			mctest.AssertEquals(t, values, []interface{}{"app-1"}, "tenant value expected")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should scope the select queries to the app/tenant, before the LIMIT and OFFSET:",
+		TestFunc: func() {
+			options := SelectQueryOptions{Skip: 20, Limit: 10, AppId: "app-1"}
+			testCases := []struct {
+				name     string
+				queryRes SelectQueryResult
+				query    string
+				values   []interface{}
+			}{
+				{
+					name:     "all",
+					queryRes: ComputeSelectQueryAll(tenantModel{}, "groups", options),
+					query:    "SELECT id FROM groups WHERE app_id=$1 LIMIT 10 OFFSET 20",
+					values:   []interface{}{"app-1"},
+				},
+				{
+					name:     "by id",
+					queryRes: ComputeSelectQueryById(tenantModel{}, "groups", "g-1", options),
+					query:    "SELECT id FROM groups WHERE id=$1 AND app_id=$2 LIMIT 10 OFFSET 20",
+					values:   []interface{}{"g-1", "app-1"},
+				},
+				{
+					name:     "by ids",
+					queryRes: ComputeSelectQueryByIds(tenantModel{}, "groups", []string{"g-1", "g-2"}, options),
+					query:    "SELECT id FROM groups WHERE id IN ('g-1', 'g-2') AND app_id=$1 LIMIT 10 OFFSET 20",
+					values:   []interface{}{"app-1"},
+				},
+				{
+					name:     "by param",
+					queryRes: ComputeSelectQueryByParam(tenantModel{}, "groups", QueryParamType{"name": "Group"}, options),
+					query:    "SELECT id FROM groups WHERE name=$1 AND app_id=$2 LIMIT 10 OFFSET 20",
+					values:   []interface{}{"Group", "app-1"},
+				},
+				{
+					name:     "by param, non-tenant mode",
+					queryRes: ComputeSelectQueryByParam(tenantModel{}, "groups", QueryParamType{"name": "Group"}, SelectQueryOptions{}),
+					query:    "SELECT id FROM groups WHERE name=$1",
+					values:   []interface{}{"Group"},
+				},
+				{
+					name:     "count",
+					queryRes: ComputeSelectCountQuery("groups", options),
+					query:    "SELECT COUNT(*) AS total_rows FROM groups WHERE app_id=$1",
+					values:   []interface{}{"app-1"},
+				},
+				{
+					name:     "count, non-tenant mode",
+					queryRes: ComputeSelectCountQuery("groups", SelectQueryOptions{}),
+					query:    "SELECT COUNT(*) AS total_rows FROM groups",
+				},
+			}
+			for _, testCase := range testCases {
+				mctest.AssertEquals(t, testCase.queryRes.Ok, true, testCase.name+": select query expected")
+				mctest.AssertEquals(t, testCase.queryRes.SelectQueryObject.SelectQuery, testCase.query, testCase.name+": select query script expected")
+				mctest.AssertEquals(t, testCase.queryRes.SelectQueryObject.FieldValues, testCase.values, testCase.name+": select query values expected")
+			}
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should scope the update and delete queries to the app/tenant:",
+		TestFunc: func() {
+			actionParam := ActionParamType{"isActive": false}
+			updateRes := ComputeUpdateQuery("groups", ActionParamsType{{"id": "g-1", "isActive": false}}, "app-1")
+			mctest.AssertEquals(t, updateRes.UpdateQueryObjects[0].UpdateQuery, "UPDATE groups SET is_active=$1 WHERE id=$2 AND app_id=$3 RETURNING id", "update query expected")
+			mctest.AssertEquals(t, updateRes.UpdateQueryObjects[0].FieldValues, []interface{}{false, "g-1", "app-1"}, "update values expected")
+			updateCases := []struct {
+				name     string
+				queryRes UpdateQueryResult
+				query    string
+				values   []interface{}
+			}{
+				{
+					name:     "update by id",
+					queryRes: ComputeUpdateQueryById("groups", actionParam, "g-1", "app-1"),
+					query:    "UPDATE groups SET is_active=$1 WHERE id=$2 AND app_id=$3 RETURNING id",
+					values:   []interface{}{false, "g-1", "app-1"},
+				},
+				{
+					name:     "update by ids",
+					queryRes: ComputeUpdateQueryByIds("groups", actionParam, []string{"g-1", "g-2"}, "app-1"),
+					query:    "UPDATE groups SET is_active=$1 WHERE id IN('g-1', 'g-2') AND app_id=$2",
+					values:   []interface{}{false, "app-1"},
+				},
+				{
+					name:     "update by param",
+					queryRes: ComputeUpdateQueryByParam("groups", actionParam, QueryParamType{"ownerId": 7}, "app-1"),
+					query:    "UPDATE groups SET is_active=$1 WHERE owner_id=$2 AND app_id=$3",
+					values:   []interface{}{false, 7, "app-1"},
+				},
+				{
+					name:     "update by id, non-tenant mode",
+					queryRes: ComputeUpdateQueryById("groups", actionParam, "g-1", ""),
+					query:    "UPDATE groups SET is_active=$1 WHERE id=$2 RETURNING id",
+					values:   []interface{}{false, "g-1"},
+				},
+			}
+			for _, testCase := range updateCases {
+				mctest.AssertEquals(t, testCase.queryRes.UpdateQueryObject.UpdateQuery, testCase.query, testCase.name+": query script expected")
+				mctest.AssertEquals(t, testCase.queryRes.UpdateQueryObject.FieldValues, testCase.values, testCase.name+": query values expected")
+			}
+			deleteCases := []struct {
+				name     string
+				queryRes DeleteQueryResult
+				query    string
+				values   []interface{}
+			}{
+				{
+					name:     "delete by id",
+					queryRes: ComputeDeleteQueryById("groups", "g-1", "app-1"),
+					query:    "DELETE FROM groups WHERE id=$1 AND app_id=$2",
+					values:   []interface{}{"g-1", "app-1"},
+				},
+				{
+					name:     "delete by ids",
+					queryRes: ComputeDeleteQueryByIds("groups", []string{"g-1", "g-2"}, "app-1"),
+					query:    "DELETE FROM groups WHERE id IN ('g-1', 'g-2') AND app_id=$1",
+					values:   []interface{}{"app-1"},
+				},
+				{
+					name:     "delete by param",
+					queryRes: ComputeDeleteQueryByParam("groups", QueryParamType{"name": "Group"}, "app-1"),
+					query:    "DELETE FROM groups WHERE name=$1 AND app_id=$2",
+					values:   []interface{}{"Group", "app-1"},
+				},
+			}
+			for _, testCase := range deleteCases {
+				mctest.AssertEquals(t, testCase.queryRes.DeleteQueryObject.DeleteQuery, testCase.query, testCase.name+": query script expected")
+				mctest.AssertEquals(t, testCase.queryRes.DeleteQueryObject.FieldValues, testCase.values, testCase.name+": query values expected")
+			}
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the tenant app-id, in multi-tenant mode only:",
+		TestFunc: func() {
+			crud := Crud{CrudParamsType: CrudParamsType{AppParams: AppParamsType{AppId: "app-1"}}}
+			mctest.AssertEquals(t, crud.TenantId(), "", "no tenant app-id expected, in non-tenant mode")
+			crud.TenantMode = true
+			mctest.AssertEquals(t, crud.TenantId(), "app-1", "tenant app-id expected")
+			query, values := crud.TenantWhere("WHERE created_by=$1", []interface{}{"user-1"})
+			mctest.AssertEquals(t, query, "WHERE created_by=$1 AND app_id=$2", "tenant where-query expected")
+			mctest.AssertEquals(t, values, []interface{}{"user-1", "app-1"}, "tenant where-values expected")
+			recs := ActionParamsType{{"name": "Group", "app_id": "app-2"}, {"name": "Other", "appId": "app-2"}}
+			crud.stampTenant(recs)
+			mctest.AssertEquals(t, recs, ActionParamsType{{"name": "Group", "appId": "app-1"}, {"name": "Other", "appId": "app-1"}}, "tenant-stamped records, without the client app-ids, expected")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -164,27 +164,40 @@ type Profile struct {
 }
 
 type RoleServiceType struct {
-	ServiceId            string   `json:"serviceId"`
-	RoleId               string   `json:"roleId"`
-	RoleIds              []string `json:"roleIds"`
-	ServiceCategory      string   `json:"serviceCategory"`
-	CanRead              bool     `json:"canRead"`
-	CanCreate            bool     `json:"canCreate"`
-	CanUpdate            bool     `json:"canUpdate"`
-	CanDelete            bool     `json:"canDelete"`
-	CanCrud              bool     `json:"canCrud"`
-	TableAccessPermitted bool     `json:"tableAccessPermitted"`
+	ServiceId            string                `json:"serviceId"`
+	RoleId               string                `json:"roleId"`
+	RoleIds              []string              `json:"roleIds"`
+	ServiceCategory      string                `json:"serviceCategory"`
+	CanRead              bool                  `json:"canRead"`
+	CanCreate            bool                  `json:"canCreate"`
+	CanUpdate            bool                  `json:"canUpdate"`
+	CanDelete            bool                  `json:"canDelete"`
+	CanCrud              bool                  `json:"canCrud"`
+	TableAccessPermitted bool                  `json:"tableAccessPermitted"`
+	FieldPermissions     []FieldPermissionType `json:"fieldPermissions"`
+	IsDeny               bool                  `json:"isDeny"` // the permitted flags (CanRead...) are denied, overrides allow role-services
+}
+
+// FieldPermissionType is the field/column-level read/write permission of a role, for a table-service
+type FieldPermissionType struct {
+	FieldName string `json:"fieldName"`
+	CanRead   bool   `json:"canRead"`
+	CanWrite  bool   `json:"canWrite"`
+	MaskRead  bool   `json:"maskRead"` // return a masked value, instead of removing the field, if CanRead is false
 }
 
 type CheckAccessType struct {
-	UserId         string            `json:"userId" mcorm:"userId"`
-	RoleId         string            `json:"roleId" mcorm:"roleId"`
-	RoleIds        []string          `json:"roleIds" mcorm:"roleIds"`
-	IsActive       bool              `json:"isActive" mcorm:"isActive"`
-	IsAdmin        bool              `json:"isAdmin" mcorm:"isAdmin"`
-	RoleServices   []RoleServiceType `json:"roleServices" mcorm:"roleServices"`
-	TableId        string            `json:"tableId" mcorm:"tableId"`
-	OwnerPermitted bool              `json:"ownerPermitted"`
+	UserId           string                `json:"userId" mcorm:"userId"`
+	RoleId           string                `json:"roleId" mcorm:"roleId"`
+	RoleIds          []string              `json:"roleIds" mcorm:"roleIds"`
+	IsActive         bool                  `json:"isActive" mcorm:"isActive"`
+	IsAdmin          bool                  `json:"isAdmin" mcorm:"isAdmin"`
+	RoleServices     []RoleServiceType     `json:"roleServices" mcorm:"roleServices"`
+	TableId          string                `json:"tableId" mcorm:"tableId"`
+	OwnerPermitted   bool                  `json:"ownerPermitted"`
+	FieldPermissions []FieldPermissionType `json:"fieldPermissions"`
+	Shares           []ShareType           `json:"shares"` // grants of the records to the user/groups
+	Decision         AccessDecisionType    `json:"decision"`
 }
 
 type CheckAccessParamsType struct {
@@ -239,6 +252,7 @@ type CrudParamsType struct {
 	TaskName      string           `json:"-"`
 	TaskType      string           `json:"-"`
 	AppParams     AppParamsType    `json:"appParams"`
+	AuditContext  AuditContextType `json:"auditContext"` // request metadata of the audit-log entries
 }
 
 type CrudOptionsType struct {
@@ -265,17 +279,52 @@ type CrudOptionsType struct {
 	UnAuthorizedMessage   string
 	RecExistMessage       string
 	CacheExpire           int
-	LoginTimeout          int
+	LoginTimeout          int // access-token lifetime in secs, default: 3600
+	RefreshTimeout        int // refresh-token lifetime in secs, default: 604800 (7 days)
 	UsernameExistsMessage string
 	EmailExistsMessage    string
 	MsgFrom               string
 	ModelOptions          ModelOptionsType
 	FieldSeparator        string
+	CheckFieldAccess      bool   // enforce field/column-level permissions, requires CheckAccess
+	FieldMaskValue        string // value for masked (read) fields, default: "********"
+	TenantMode            bool   // validate AppParams and scope records to the app (app_id)
+	AppTable              string
+	Authenticator         Authenticator  // user-token validation, default: DbTokenAuthenticator
+	PasswordHasher        PasswordHasher // default: BcryptPasswordHasher
+	Mailer                Mailer         // verification and password-reset messages delivery
+	VerifyTimeout         int            // verification/reset-token lifetime in secs, default: 86400 (1 day)
+	RoleHierarchy         bool           // evaluate all the user roles (roleIds) and the inherited (parent) roles
+	RoleInheritTable      string         // role inheritance (role_id, parent_role_id), default: role_inherits
+	RoleCacheExpire       int            // effective role-services cache expire in secs, default: 300
+	PolicyCheck           bool           // evaluate the attribute-based access policies, before and after the role check
+	PolicyTable           string         // default: policies
+	Policies              []PolicyRuleType
+	LogAccessDenial       bool              // persist the denied access decisions (DenyLog), through the audit logger
+	ShareAccess           bool              // honour the per-record shares/grants to users and groups (roles)
+	ShareTable            string            // default: shares
+	AutoRegisterService   bool              // register the crud table as table-service, if not found, for the access check of the admin users
+	RoleDefinitionTable   string            // role definitions (name, description, is_active), default: role_definitions
+	AccessCache           bool              // cache the user access information (by token), services and role-services
+	AccessCacheExpire     int               // access cache expire in secs, bounded by the token expire, default: 60
+	AuditDiff             bool              // store the update-log field-level changes, requires the audit-table log_diff column
+	AuditHashChain        bool              // hash-chained (tamper-evident) audit-log, requires the audit-table chain_key, chain_seq, prev_hash and hash columns
+	AuditChainScope       string            // hash-chain per table (AuditChainTableScope) or global (AuditChainGlobalScope), default: table
+	AuditChainTable       string            // hash-chain heads table, default: audit_chains
+	AuditWriter           *AsyncAuditWriter // asynchronous audit-log writer, shared by the crud instances, Close on shutdown
+	AuditLogger           AuditLogger       // audit-log sink, e.g. JsonlAuditSink or FanOutAuditSink, default: the audit table (LogParamX)
+	AuditLogContext       bool              // store the audit context (request_id, correlation_id, client_ip, user_agent, app_id, reason columns)
+	AuditRedactRules      []RedactRuleType  // audit payload redaction rules, applied with (and overriding) the DefaultRedactRules
+	AuditRedactKey        []byte            // HMAC key of the hash redaction action, if specified
+	CacheTags             []string          // related (e.g. joined) tables of the cached queries, invalidated by the related tables writes
+	Cache                 Cache             // query-results cache, e.g. NewLRUCache, NewRedisCache or NewNoopCache, default: DefaultCache
+	CacheSharedTables     []string          // tables of the non user-specific data, cached for all the users (of the tenant), default: cached by user/role access scope
 }
 
 type SelectQueryOptions struct {
 	Skip  int
 	Limit int
+	AppId string // scopes the select query to the app/tenant (app_id), in multi-tenant mode
 }
 
 type MessageObject map[string]string
@@ -76,11 +76,16 @@ func ArrayIntContains(arr []int, val int) bool {
 	return false
 }
 
+// QuoteSQLString returns the SQL-string-literal of val, with embedded single-quotes escaped
+func QuoteSQLString(val string) string {
+	return "'" + strings.Replace(val, "'", "''", -1) + "'"
+}
+
 // ArrayToSQLStringValues transforms a slice of string to SQL-string-formatted-values
 func ArrayToSQLStringValues(arr []string) string {
 	result := ""
 	for ind, val := range arr {
-		result += "'" + val + "'"
+		result += QuoteSQLString(val)
 		if ind < len(arr)-1 {
 			result += ", "
 		}